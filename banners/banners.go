@@ -0,0 +1,9 @@
+// Package banners embeds the default banner files so the binary runs from any directory
+package banners
+
+import "embed"
+
+// FS holds the built-in banner files
+//
+//go:embed *.txt
+var FS embed.FS
@@ -3,10 +3,12 @@ package main
 import (
 	"ascii-art/internal/ascii"
 	color "ascii-art/internal/ascii-color"
+	input "ascii-art/internal/ascii-input"
 	justify "ascii-art/internal/ascii-justify"
 	output "ascii-art/internal/ascii-output"
 	reverse "ascii-art/internal/ascii-reverse"
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -14,33 +16,246 @@ func main() {
 	// Obtain user arguments from command line
 	args := os.Args[1:]
 
-	// Priority 1: Check for --reverse flag first (takes precedence over everything)
+	// Priority 1: Parse --banner-dir flag so every feature searches the same banners
+	bannerDir, args, err := ascii.ParseBannerDirFlag(args)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	ascii.Banners = ascii.NewRegistry(bannerDir)
+
+	// Priority 2: Check for --reverse flag (takes precedence over everything else)
 	if reverse.HasReverseFlag(args) {
 		reverse.HandleReverse(args)
 		return
 	}
 
-	// Priority 2: Parse --align flag
+	// Priority 3: Parse --align flag
 	alignType, remainingArgs, err := justify.ParseAlignFlag(args)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
-	// Priority 3: Parse --output flag
+	// Priority 4: Parse --output flag
 	outputFile, remainingArgs, err := output.ParseOutputFlag(remainingArgs)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
+	// Priority 5: Parse --layout flag
+	layout, remainingArgs, err := ascii.ParseLayoutFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 6: Parse --vlayout flag
+	vlayout, remainingArgs, err := ascii.ParseVLayoutFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 7: Parse --fallback flag
+	fallback, remainingArgs, err := ascii.ParseFallbackFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 8: Parse --width and --hyphenate flags
+	width, remainingArgs, err := ascii.ParseWidthFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	hyphenate, remainingArgs, err := ascii.ParseHyphenateFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 9: Parse --raw, --tab-width and --control flags
+	raw, remainingArgs, err := ascii.ParseRawFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	tabWidth, remainingArgs, err := ascii.ParseTabWidthFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	control, remainingArgs, err := ascii.ParseControlFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	ascii.Normalization = ascii.TextOptions{Raw: raw, TabWidth: tabWidth, Control: control}
+
+	// Priority 10: Parse --scale, --scale-x and --scale-y flags
+	scaleX, scaleY, remainingArgs, err := ascii.ParseScaleFlags(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 11: Parse --letter-spacing and --line-spacing flags
+	letterSpacing, lineSpacing, remainingArgs, err := ascii.ParseSpacingFlags(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 12: Parse --workers flag
+	workers, remainingArgs, err := ascii.ParseWorkersFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 13: Parse --direction and --transform flags
+	direction, remainingArgs, err := ascii.ParseDirectionFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	transform, remainingArgs, err := ascii.ParseTransformFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 14: Parse --fill flag
+	fill, remainingArgs, err := ascii.ParseFillFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 15: Parse --effect flag
+	effect, remainingArgs, err := ascii.ParseEffectFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 16: Parse --invert flag
+	invert, remainingArgs, err := ascii.ParseInvertFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 17: Parse --shadow and --extrude flags
+	shadow, remainingArgs, err := ascii.ParseShadowFlags(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 18: Parse --shadow-color flag
+	shadowColor, remainingArgs, err := color.ParseShadowColorFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 19: Parse --border, --padding and --margin flags
+	border, remainingArgs, err := ascii.ParseBorderFlags(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Priority 20: Parse --border-color flag
+	borderColor, remainingArgs, err := color.ParseBorderColorFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Without --width, art printed to a terminal wraps to the terminal width
+	width = ascii.WrapWidth(width, outputFile == "" && justify.IsTerminal(), justify.GetTerminalWidth)
+
+	opts := ascii.Options{
+		Layout:        layout,
+		VLayout:       vlayout,
+		Fallback:      fallback,
+		Width:         width,
+		Hyphenate:     hyphenate,
+		ScaleX:        scaleX,
+		ScaleY:        scaleY,
+		LetterSpacing: letterSpacing,
+		LineSpacing:   lineSpacing,
+		Workers:       workers,
+		Direction:     direction,
+		Transform:     transform,
+		Fill:          fill,
+		Effect:        effect,
+		Invert:        invert,
+		Shadow:        shadow,
+		Border:        border,
+	}
+
+	// Priority 21: Parse --list-banners and --preview flags, which show every banner instead of rendering
+	listBanners, remainingArgs, err := ascii.ParseListBannersFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	preview, remainingArgs, err := ascii.ParsePreviewFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if listBanners || preview != "" {
+		if err := showBanners(outputFile, remainingArgs, listBanners, preview, opts); err != nil {
+			fmt.Println(err)
+		}
+		return
+	}
+
+	// Priority 22: Parse --banner and --input flags
+	bannerName, remainingArgs, err := ascii.ParseBannerFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	inputFile, remainingArgs, err := input.ParseInputFlag(remainingArgs)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Without text on the command line, piped text is read from stdin
+	if inputFile == "" && !input.HasText(remainingArgs) && input.IsStdinPiped() {
+		inputFile = input.Stdin
+	}
+
+	// --banner stands for the trailing banner argument
+	if bannerName != "" {
+		remainingArgs = append(remainingArgs, bannerName)
+	}
+
+	// Text from a file or stdin is rendered line by line as it streams in
+	if inputFile != "" {
+		err = streamInput(inputFile, outputFile, remainingArgs, alignType, shadowColor, borderColor, opts)
+		if err != nil {
+			fmt.Println(err)
+		}
+		return
+	}
+
 	// Temporarily replace os.Args with remaining args for color parsing
-	// This allows GetUserInputWithColor to work as if --align and --output flags weren't there
+	// This allows GetUserInputWithColor to work as if the flags parsed above weren't there
 	originalArgs := os.Args
 	os.Args = append([]string{os.Args[0]}, remainingArgs...)
 
-	// Priority 4: Get user input and banner choice
-	input, banner, colorConfig, err := color.GetUserInputWithColor()
+	// Priority 23: Get user input and banner choice
+	text, banner, colorConfig, err := color.GetUserInputWithColor()
 
 	// Restore original args
 	os.Args = originalArgs
@@ -49,6 +264,9 @@ func main() {
 		fmt.Println(err)
 		return
 	}
+	colorConfig.Shadow = shadowColor
+	colorConfig.Border = borderColor
+	colorConfig.Invert = opts.Invert != 0
 
 	// Load the ASCII banner from the specified file
 	result, err := ascii.LoadBannerFile(banner)
@@ -57,30 +275,122 @@ func main() {
 		return
 	}
 
-	// Create the render function that will be executed
-	renderFunc := func() {
-		if colorConfig.Enabled {
-			color.RenderAsciiWithColor(input, result, colorConfig)
-		} else {
-			ascii.RenderAscii(input, result)
-		}
+	// Refuse missing characters up front when the fallback policy asks for an error
+	if err := ascii.CheckGlyphs(text, result, opts); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Render the ASCII art once, then route the lines to the chosen output
+	// Justified art is rendered word by word, so its words can be spread across the width
+	// Art without color needs no cell sources, so its rows are written straight from the banner
+	justified := alignType == "justify" && outputFile == ""
+	var lines []string
+	switch {
+	case justified:
+		canvas := justify.JustifyCanvas(text, 0, result, opts, justify.AlignWidth(opts.Width))
+		lines, err = color.Colorize(canvas, text, colorConfig)
+	case colorConfig.Colored():
+		lines, err = color.Colorize(ascii.Render(text, result, opts), text, colorConfig)
+	default:
+		lines = ascii.RenderPlain(text, result, opts)
+	}
+	if err != nil {
+		fmt.Println(err)
+		return
 	}
 
 	// Handle output with alignment
 	if outputFile != "" {
 		// If output flag is set, write to file (alignment not applied to file output)
-		err = output.HandleOutput(outputFile, renderFunc)
+		err = output.HandleOutput(outputFile, func(w io.Writer) {
+			ascii.WriteLines(w, lines)
+		})
 		if err != nil {
 			fmt.Println(err)
 			return
 		}
+	} else if justified {
+		if err := ascii.WriteLines(os.Stdout, lines); err != nil {
+			fmt.Println(err)
+			return
+		}
 	} else {
 		// No output file, apply alignment to stdout
-		// Pass input and banner for justify to work properly
-		err = justify.HandleJustify(renderFunc, alignType, input, result)
+		err = justify.HandleJustify(os.Stdout, lines, alignType, text, result, opts)
 		if err != nil {
 			fmt.Println(err)
 			return
 		}
 	}
 }
+
+// showBanners lists every banner on the search path and previews text in each, to stdout or outputFile
+// args are the arguments left after the flags, which must be empty
+func showBanners(outputFile string, args []string, list bool, preview string, opts ascii.Options) error {
+	if len(args) > 0 {
+		return ascii.ErrBannerListArgs
+	}
+
+	text, err := ascii.NormalizeText(preview, ascii.Normalization)
+	if err != nil {
+		return err
+	}
+
+	infos := ascii.Banners.List()
+	var writeErr error
+	err = output.HandleOutput(outputFile, func(w io.Writer) {
+		if list {
+			writeErr = ascii.WriteBannerList(w, infos)
+		}
+		if writeErr == nil && text != "" {
+			if list {
+				fmt.Fprintln(w)
+			}
+			writeErr = ascii.WritePreview(w, infos, text, opts)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return writeErr
+}
+
+// streamInput renders the text of inputFile, or stdin, to stdout or outputFile as it is read
+// args are the arguments left after the flags: an optional color flag, substring and banner
+func streamInput(inputFile, outputFile string, args []string, alignType, shadowColor, borderColor string, opts ascii.Options) error {
+	banner, colorConfig, err := color.GetStreamArgsWithColor(args)
+	if err != nil {
+		return err
+	}
+	colorConfig.Shadow = shadowColor
+	colorConfig.Border = borderColor
+	colorConfig.Invert = opts.Invert != 0
+
+	font, err := ascii.LoadBannerFile(banner)
+	if err != nil {
+		return err
+	}
+
+	r, err := input.OpenInput(inputFile)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	cfg := input.StreamConfig{Font: font, Options: opts, Text: ascii.Normalization, Color: colorConfig}
+
+	// Alignment is only applied to stdout, like the rest of the output
+	if outputFile != "" {
+		file, err := output.CreateFile(outputFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return input.HandleStream(r, file, cfg)
+	}
+
+	cfg.Align = alignType
+	cfg.Width = justify.AlignWidth(opts.Width)
+	return input.HandleStream(r, os.Stdout, cfg)
+}
@@ -0,0 +1,52 @@
+package ascii
+
+import (
+	"ascii-art/internal/ascii"
+)
+
+// Colorizer colors the canvases of an ascii.Stream as the input arrives one line at a time
+// It only remembers the colored characters that rows still to come can draw
+type Colorizer struct {
+	config   ColorConfig
+	codes    ansiCodes
+	colorMap map[int]bool // Colored byte offsets in the whole input
+	offset   int          // Byte offset of the next line in the whole input
+}
+
+// NewColorizer creates a Colorizer for colorConfig, checking its colors up front
+func NewColorizer(colorConfig ColorConfig) (*Colorizer, error) {
+	codes, err := parseCodes(colorConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Colorizer{config: colorConfig, codes: codes, colorMap: make(map[int]bool)}, nil
+}
+
+// Add records which characters of the next line of input, without its newline, are colored
+func (c *Colorizer) Add(line string) {
+	if c.config.Enabled {
+		for i, colored := range BuildColorMap(line, c.config.Substring) {
+			if colored {
+				c.colorMap[c.offset+i] = true
+			}
+		}
+	}
+	c.offset += len(line) + 1
+}
+
+// Colorize converts canvas rows to lines, coloring the cells drawn by the selected characters
+func (c *Colorizer) Colorize(canvas *ascii.Canvas) []string {
+	if !c.config.Colored() {
+		return canvas.Lines()
+	}
+	return colorRows(canvas, c.colorMap, c.codes)
+}
+
+// Forget drops the characters before offset, once no row left to render can draw them
+func (c *Colorizer) Forget(offset int) {
+	for src := range c.colorMap {
+		if src < offset {
+			delete(c.colorMap, src)
+		}
+	}
+}
@@ -10,11 +10,25 @@ import (
 var errInvalidColorFlag = errors.New(`Usage: go run ./cmd [OPTION] [STRING]
 EX: go run ./cmd --color=<color> <substring to be colored> "something"`)
 
+var errInvalidBorderColorFlag = errors.New(`Usage: go run ./cmd [OPTION] [STRING]
+EX: go run ./cmd --border=<style> --border-color=<color> "something"`)
+
+var errInvalidShadowColorFlag = errors.New(`Usage: go run ./cmd [OPTION] [STRING]
+EX: go run ./cmd --shadow=<dx>,<dy> --shadow-color=<color> "something"`)
+
 // ColorConfig holds color configuration
 type ColorConfig struct {
 	Enabled   bool   // Whether color is enabled
 	Color     string // The color (parsed later by ParseColor)
 	Substring string // Substring to color (empty = color entire string)
+	Border    string // Color of a frame drawn around the art (empty = uncolored), set by --border-color
+	Shadow    string // Color of a shadow drawn behind the art (empty = uncolored), set by --shadow-color
+	Invert    bool   // Whether the art is inverted, so Color fills its background, set by --invert
+}
+
+// Colored reports whether the config colors any part of the art
+func (c ColorConfig) Colored() bool {
+	return c.Enabled || c.Border != "" || c.Shadow != ""
 }
 
 // GetUserInputWithColor parses arguments including color flags
@@ -70,7 +84,7 @@ func parseWithColorFlag(args []string) (string, string, ColorConfig, error) {
 
 	// Check if last argument is a valid banner
 	lastArg := args[len(args)-1]
-	hasBanner := ascii.ValidBanners[lastArg]
+	hasBanner := ascii.IsValidBanner(lastArg)
 
 	if hasBanner {
 		// Banner is specified
@@ -100,16 +114,18 @@ func parseWithColorFlag(args []string) (string, string, ColorConfig, error) {
 		return "", "", ColorConfig{}, errInvalidColorFlag
 	}
 
-	// Process input (handle \n and trim)
-	input = strings.TrimSpace(input)
-	input = strings.ReplaceAll(input, "\\n", "\n")
+	// Process input (trim, then decode escapes, expand tabs and drop control characters)
+	input, err := ascii.NormalizeText(strings.TrimSpace(input), ascii.Normalization)
+	if err != nil {
+		return "", "", ColorConfig{}, err
+	}
 
 	if input == "" {
 		return "", "", ColorConfig{}, ascii.ErrMissingInput
 	}
 
 	// Validate color format (try to parse it)
-	_, err := ParseColor(color)
+	_, err = ParseColor(color)
 	if err != nil {
 		return "", "", ColorConfig{}, err
 	}
@@ -122,3 +138,86 @@ func parseWithColorFlag(args []string) (string, string, ColorConfig, error) {
 
 	return input, banner, colorConfig, nil
 }
+
+// GetStreamArgsWithColor parses the arguments left when the text comes from --input or stdin
+// Accepts: [BANNER] or --color=<color> [substring] [BANNER]
+// Returns: banner name, color config, error
+func GetStreamArgsWithColor(args []string) (string, ColorConfig, error) {
+	if len(args) == 0 || !strings.HasPrefix(args[0], "--color") {
+		banner, err := ascii.GetStreamBanner(args)
+		return banner, ColorConfig{Enabled: false}, err
+	}
+
+	if !strings.HasPrefix(args[0], "--color=") {
+		return "", ColorConfig{}, errInvalidColorFlag
+	}
+
+	color := strings.TrimPrefix(args[0], "--color=")
+	if color == "" {
+		return "", ColorConfig{}, errInvalidColorFlag
+	}
+	if _, err := ParseColor(color); err != nil {
+		return "", ColorConfig{}, err
+	}
+	args = args[1:]
+
+	// A trailing banner name is taken as the banner, like parseWithColorFlag does
+	banner := "standard"
+	if len(args) > 0 && ascii.IsValidBanner(args[len(args)-1]) {
+		banner = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+
+	substring := ""
+	switch len(args) {
+	case 0:
+	case 1:
+		substring = args[0]
+	default:
+		return "", ColorConfig{}, ascii.ErrTextWithInput
+	}
+
+	return banner, ColorConfig{Enabled: true, Color: color, Substring: substring}, nil
+}
+
+// ParseBorderColorFlag extracts and validates the --border-color flag
+// Returns: border color (empty when the flag is absent), remainingArgs, error
+func ParseBorderColorFlag(args []string) (string, []string, error) {
+	return parseNamedColorFlag(args, "--border-color", errInvalidBorderColorFlag)
+}
+
+// ParseShadowColorFlag extracts and validates the --shadow-color flag
+// Returns: shadow color (empty when the flag is absent), remainingArgs, error
+func ParseShadowColorFlag(args []string) (string, []string, error) {
+	return parseNamedColorFlag(args, "--shadow-color", errInvalidShadowColorFlag)
+}
+
+// parseNamedColorFlag extracts and validates a color flag called name, returning errFormat when it has no value
+func parseNamedColorFlag(args []string, name string, errFormat error) (string, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == name {
+			return "", nil, errFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, name+"=") {
+			color := strings.TrimPrefix(arg, name+"=")
+			if color == "" {
+				return "", nil, errFormat
+			}
+			if _, err := ParseColor(color); err != nil {
+				return "", nil, err
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return color, remaining, nil
+		}
+	}
+
+	// No color flag found
+	return "", args, nil
+}
@@ -1,58 +1,166 @@
 package ascii
 
 import (
+	"ascii-art/internal/ascii"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"unicode/utf8"
 )
 
-// RenderAsciiWithColor renders ASCII art with color support
-func RenderAsciiWithColor(input string, banner map[rune][]string, colorConfig ColorConfig) {
-	// Parse the color to get ANSI code
-	ansiCode, err := ParseColor(colorConfig.Color)
+// RenderColorLines builds the colored ASCII art rows for input without printing them
+func RenderColorLines(input string, font *ascii.Font, colorConfig ColorConfig) ([]string, error) {
+	return Colorize(ascii.Render(input, font, ascii.Options{}), input, colorConfig)
+}
+
+// Colorize converts a rendered canvas to lines, coloring the cells drawn by the selected characters
+// input must be the text the canvas was rendered from so cell sources can be matched to it
+// A frame and a shadow drawn around the art have colors of their own, whether or not the text is colored.
+func Colorize(canvas *ascii.Canvas, input string, colorConfig ColorConfig) ([]string, error) {
+	if !colorConfig.Colored() {
+		return canvas.Lines(), nil
+	}
+
+	codes, err := parseCodes(colorConfig)
 	if err != nil {
-		// This shouldn't happen as we validated earlier, but just in case
-		fmt.Println("Error:", err)
-		return
+		return nil, err
 	}
 
-	lines := strings.Split(input, "\n")
+	// Determine which characters of the whole input to color
+	colorMap := make(map[int]bool)
+	if colorConfig.Enabled {
+		colorMap = BuildInputColorMap(input, colorConfig.Substring)
+	}
 
-	for _, line := range lines {
-		// If line is empty, just print newline
-		if line == "" {
-			fmt.Println()
-			continue
-		}
+	return colorRows(canvas, colorMap, codes), nil
+}
 
-		// Determine which characters to color
-		colorMap := BuildColorMap(line, colorConfig.Substring)
+// ansiCodes are the escape codes cells are drawn with
+type ansiCodes struct {
+	text       string // Code for the selected characters
+	background string // Background code the selected characters of inverted art are drawn with, empty when not inverted
+	border     string // Code for the frame, empty to leave it uncolored
+	shadow     string // Code for the shadow, empty to leave it uncolored
+}
 
-		// Build and print each of the 8 ASCII lines
-		for row := 0; row < 8; row++ {
-			var outputLine string
+// parseCodes converts the colors of colorConfig to ANSI escape codes
+func parseCodes(colorConfig ColorConfig) (ansiCodes, error) {
+	var codes ansiCodes
+	var err error
+	if colorConfig.Enabled {
+		if codes.text, err = ParseColor(colorConfig.Color); err != nil {
+			return ansiCodes{}, err
+		}
+		if colorConfig.Invert {
+			codes.background = strings.Replace(codes.text, "[38;", "[48;", 1)
+		}
+	}
+	if colorConfig.Border != "" {
+		if codes.border, err = ParseColor(colorConfig.Border); err != nil {
+			return ansiCodes{}, err
+		}
+	}
+	if colorConfig.Shadow != "" {
+		if codes.shadow, err = ParseColor(colorConfig.Shadow); err != nil {
+			return ansiCodes{}, err
+		}
+	}
+	return codes, nil
+}
+
+// colorRows converts canvas rows to strings, wrapping cells whose source is in colorMap in the text code,
+// frame cells in the border code and shadow cells in the shadow code
+// Inverted art draws the background of the selected characters as blocks of the background code instead.
+func colorRows(canvas *ascii.Canvas, colorMap map[int]bool, codes ansiCodes) []string {
+	result := make([]string, len(canvas.Rows))
+	for i, row := range canvas.Rows {
+		var sb strings.Builder
+		sb.Grow(len(row))
+		current := ""
 
-			for charIndex, ch := range line {
-				if asciiChar, ok := banner[ch]; ok {
-					charArt := asciiChar[row]
+		// Neighbouring cells mostly come from the same character, so its lookup is reused
+		lastSrc, lastColored := -1, false
 
-					// Apply color if this character should be colored
-					if colorMap[charIndex] {
-						charArt = ansiCode + charArt + ResetColor()
-					}
+		for _, cell := range row {
+			if cell.Src >= 0 && cell.Src != lastSrc {
+				lastSrc, lastColored = cell.Src, colorMap[cell.Src]
+			}
 
-					outputLine += charArt
+			// Open, switch or close the color whenever the cell needs a different one
+			code, ch := "", cell.Ch
+			if cell.Src >= 0 && lastColored && codes.background != "" {
+				if ch != ' ' {
+					code, ch = codes.background, ' '
+				}
+			} else if cell.Src >= 0 && lastColored {
+				code = codes.text
+			} else if cell.Src == ascii.SrcBorder {
+				code = codes.border
+			} else if cell.Src == ascii.SrcShadow {
+				code = codes.shadow
+			}
+			if code != current {
+				if code != "" {
+					sb.WriteString(code)
 				} else {
-					// Character not in banner, use placeholder
-					outputLine += "        " // 8 spaces
+					sb.WriteString(ResetColor())
 				}
+				current = code
 			}
+			if ch < utf8.RuneSelf {
+				sb.WriteByte(byte(ch))
+			} else {
+				sb.WriteRune(ch)
+			}
+		}
+
+		if current != "" {
+			sb.WriteString(ResetColor())
+		}
+		result[i] = sb.String()
+	}
+
+	return result
+}
+
+// RenderAsciiWithColorTo writes the colored ASCII art to w
+func RenderAsciiWithColorTo(w io.Writer, input string, font *ascii.Font, colorConfig ColorConfig) error {
+	lines, err := RenderColorLines(input, font, colorConfig)
+	if err != nil {
+		return err
+	}
+	return ascii.WriteLines(w, lines)
+}
 
-			fmt.Println(outputLine)
+// RenderAsciiWithColor renders ASCII art with color support to stdout
+func RenderAsciiWithColor(input string, font *ascii.Font, colorConfig ColorConfig) {
+	err := RenderAsciiWithColorTo(os.Stdout, input, font, colorConfig)
+	if err != nil {
+		// This shouldn't happen as we validated earlier, but just in case
+		fmt.Println("Error:", err)
+	}
+}
+
+// BuildInputColorMap determines which byte offsets of a multi-line input should be colored
+// Substrings are matched within each line, like BuildColorMap
+func BuildInputColorMap(input string, substring string) map[int]bool {
+	colorMap := make(map[int]bool)
+	offset := 0
+
+	for _, line := range strings.Split(input, "\n") {
+		for i, colored := range BuildColorMap(line, substring) {
+			if colored {
+				colorMap[offset+i] = true
+			}
 		}
+		offset += len(line) + 1
 	}
+
+	return colorMap
 }
 
-// buildColorMap determines which character indices should be colored
+// BuildColorMap determines which character indices should be colored
 // Returns a map of character index -> should color (true/false)
 func BuildColorMap(line string, substring string) map[int]bool {
 	colorMap := make(map[int]bool)
@@ -0,0 +1,21 @@
+package asciiinput
+
+import "fmt"
+
+// Usage message for the input feature
+const UsageInput = `Usage: go run ./cmd [OPTION] [BANNER]
+
+EX: go run ./cmd --input=notes.txt standard
+EX: echo "deploy ok" | go run ./cmd --banner=shadow
+EX: go run ./cmd --input=- shadow < notes.txt`
+
+// Error messages
+var (
+	// ErrInvalidInputFormat is returned when the --input flag format is incorrect
+	ErrInvalidInputFormat = fmt.Errorf("invalid --input flag format\n%s", UsageInput)
+)
+
+// WrapInputOpenError wraps errors opening the input file with additional context
+func WrapInputOpenError(filename string, err error) error {
+	return fmt.Errorf("failed to open input %q: %w", filename, err)
+}
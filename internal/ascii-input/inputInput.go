@@ -0,0 +1,62 @@
+package asciiinput
+
+import (
+	"ascii-art/internal/ascii"
+	"os"
+	"strings"
+)
+
+// Stdin is the --input value that reads the text from standard input
+const Stdin = "-"
+
+// ParseInputFlag extracts and validates the --input flag
+// Returns: input file (Stdin for "-", empty when the flag is absent), remainingArgs, error
+func ParseInputFlag(args []string) (string, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--input" {
+			return "", nil, ErrInvalidInputFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--input=") {
+			filename := strings.TrimPrefix(arg, "--input=")
+			if strings.TrimSpace(filename) == "" {
+				return "", nil, ErrInvalidInputFormat
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return filename, remaining, nil
+		}
+	}
+
+	// No input flag found
+	return "", args, nil
+}
+
+// HasText reports whether args hold text to render, anything besides a --color flag
+// A lone banner name is the banner for piped text, not text of its own.
+func HasText(args []string) bool {
+	var positional []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--color") {
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 1 {
+		return !ascii.IsValidBanner(positional[0])
+	}
+	return len(positional) > 0
+}
+
+// IsStdinPiped reports whether standard input is a pipe or a file rather than a terminal
+func IsStdinPiped() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
+}
@@ -0,0 +1,48 @@
+package asciiinput
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// OpenInput opens the input file, or standard input for Stdin
+// Closing the returned reader never closes standard input
+func OpenInput(filename string) (io.ReadCloser, error) {
+	if filename == Stdin {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, WrapInputOpenError(filename, err)
+	}
+	return file, nil
+}
+
+// ReadLines calls fn with each line of r, without its line ending, as soon as it is read
+// Lines of any length are supported; reading stops at the first error from fn
+func ReadLines(r io.Reader, fn func(line string) error) error {
+	reader := bufio.NewReader(r)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		// A final line without a newline still counts, an empty remainder does not
+		if line != "" {
+			line = strings.TrimSuffix(line, "\n")
+			line = strings.TrimSuffix(line, "\r")
+			if fnErr := fn(line); fnErr != nil {
+				return fnErr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package asciiinput
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	"io"
+)
+
+// StreamConfig holds the settings used to render streamed input
+type StreamConfig struct {
+	Font    *ascii.Font       // Banner the text is drawn with
+	Options ascii.Options     // Layout, fallback and wrapping options
+	Text    ascii.TextOptions // Tab stops and control characters; escapes are never decoded
+	Color   color.ColorConfig // Color applied to the art
+	Align   string            // Alignment type, empty to leave lines as rendered
+	Width   int               // Width lines are aligned within
+}
+
+// HandleStream renders each line of r as soon as it is read and writes the art to w
+// Only the rows that vertical layout may still change are kept between lines.
+// Justified lines are rendered word by word, so each one is stacked on its own.
+// A transform, effect, inversion, shadow or border needs the whole block, so with one nothing is written until the input ends.
+// Streamed text is taken literally: it already holds real tabs and newlines.
+func HandleStream(r io.Reader, w io.Writer, cfg StreamConfig) error {
+	colorizer, err := color.NewColorizer(cfg.Color)
+	if err != nil {
+		return err
+	}
+	textOpts := cfg.Text
+	textOpts.Raw = true
+
+	// Vertical columns are only complete at the end of the input, so they are centred then instead of justified
+	if cfg.Options.Direction == ascii.DirectionVertical && cfg.Align == "justify" {
+		cfg.Align = "center"
+	}
+
+	// write colors and aligns the rows that are final, then drops colors no row after held needs
+	write := func(canvas *ascii.Canvas, held int) error {
+		lines := colorizer.Colorize(canvas)
+		colorizer.Forget(held)
+		return ascii.WriteLines(w, justify.ApplyAlignment(lines, cfg.Align, cfg.Width, cfg.Font.Height))
+	}
+
+	// add renders a line and writes the rows it finalises, finish writes the rows held back
+	stream := ascii.NewStream(cfg.Font, cfg.Options)
+	add := func(line string) error {
+		colorizer.Add(line)
+		return write(stream.Add(line), stream.Held())
+	}
+	finish := func() error {
+		return write(stream.Flush(), 0)
+	}
+	abort := func() {}
+
+	// With several workers, lines are rendered in parallel and written in input order
+	if cfg.Options.Workers > 1 && cfg.Align != "justify" {
+		var parallel *ascii.ParallelStream
+		parallel = ascii.NewParallelStream(cfg.Font, cfg.Options, cfg.Options.Workers, func(line string, canvas *ascii.Canvas) error {
+			colorizer.Add(line)
+			return write(canvas, parallel.Held())
+		})
+
+		add = parallel.Add
+		finish = func() error {
+			rest, err := parallel.Close()
+			if err != nil {
+				return err
+			}
+			return write(rest, 0)
+		}
+		abort = func() {
+			parallel.Close() // lines already queued are still written, like the serial renderer
+		}
+	}
+
+	// Justified rows are held for a transform, effect, inversion, shadow or border, which work on the block as a whole
+	justifyOpts := cfg.Options
+	justifyOpts.Transform, justifyOpts.Effect, justifyOpts.Invert = ascii.TransformNone, ascii.Effect{}, 0
+	justifyOpts.Shadow, justifyOpts.Border = ascii.Shadow{}, ascii.Border{}
+	justifyOpts.Width = cfg.Options.InnerWidth(cfg.Options.Width)
+	justifyWidth := cfg.Options.InnerWidth(cfg.Width)
+	var justified [][]ascii.Cell
+	read, offset := 0, 0
+
+	err = ReadLines(r, func(line string) error {
+		line, err := ascii.NormalizeText(line, textOpts)
+		if err != nil {
+			return err
+		}
+
+		// Refuse missing characters before any of the line is written
+		if err := ascii.CheckGlyphs(line, cfg.Font, cfg.Options); err != nil {
+			return err
+		}
+
+		if cfg.Align == "justify" {
+			colorizer.Add(line)
+			canvas := justify.JustifyCanvas(line, offset, cfg.Font, justifyOpts, justifyWidth)
+			offset += len(line) + 1
+			_, sy := cfg.Options.Scale()
+			if len(canvas.Rows) == 0 {
+				canvas.Rows = make([][]ascii.Cell, sy) // an empty line still takes a row
+			}
+
+			// Each line is justified on its own, so the line spacing above it is added here
+			if read++; read > 1 && cfg.Options.LineSpacing > 0 {
+				gap := make([][]ascii.Cell, cfg.Options.LineSpacing*sy)
+				for i := range gap {
+					gap[i] = justify.BlankCells(max(canvas.Width(), 1))
+				}
+				canvas.Rows = append(gap, canvas.Rows...)
+			}
+			if cfg.Options.WholeBlock() {
+				justified = append(justified, canvas.Rows...)
+				return nil
+			}
+			lines := colorizer.Colorize(canvas)
+			colorizer.Forget(offset)
+			return ascii.WriteLines(w, lines)
+		}
+
+		return add(line)
+	})
+	if err != nil {
+		abort()
+		return err
+	}
+
+	if cfg.Align == "justify" && cfg.Options.WholeBlock() {
+		canvas := justify.FinishJustified(&ascii.Canvas{Rows: justified}, cfg.Options, cfg.Width)
+		return ascii.WriteLines(w, colorizer.Colorize(canvas))
+	}
+	return finish()
+}
@@ -1,12 +1,14 @@
 package asciijustify
 
 import (
+	"ascii-art/internal/ascii"
 	"strings"
 )
 
 // ApplyAlignment applies the specified alignment to ASCII art lines
+// height is the glyph height of the font that produced the lines
 // Returns a new slice with aligned lines
-func ApplyAlignment(asciiLines []string, alignType string, terminalWidth int) []string {
+func ApplyAlignment(asciiLines []string, alignType string, terminalWidth int, height int) []string {
 	if len(asciiLines) == 0 {
 		return asciiLines
 	}
@@ -19,7 +21,7 @@ func ApplyAlignment(asciiLines []string, alignType string, terminalWidth int) []
 	case "right":
 		return RightAlign(asciiLines, terminalWidth)
 	case "justify":
-		return JustifyAlign(asciiLines, terminalWidth)
+		return JustifyAlign(asciiLines, terminalWidth, height)
 	default:
 		return asciiLines
 	}
@@ -29,7 +31,7 @@ func ApplyAlignment(asciiLines []string, alignType string, terminalWidth int) []
 func CenterAlign(lines []string, termWidth int) []string {
 	result := make([]string, len(lines))
 	for i, line := range lines {
-		lineWidth := len(line)
+		lineWidth := GetLineWidth(line)
 		if lineWidth >= termWidth {
 			result[i] = line
 			continue
@@ -44,7 +46,7 @@ func CenterAlign(lines []string, termWidth int) []string {
 func RightAlign(lines []string, termWidth int) []string {
 	result := make([]string, len(lines))
 	for i, line := range lines {
-		lineWidth := len(line)
+		lineWidth := GetLineWidth(line)
 		if lineWidth >= termWidth {
 			result[i] = line
 			continue
@@ -56,16 +58,19 @@ func RightAlign(lines []string, termWidth int) []string {
 }
 
 // JustifyAlign distributes words evenly across terminal width
-// For ASCII art, each group of 8 lines represents one line of text
-func JustifyAlign(lines []string, termWidth int) []string {
-	// Justify works on groups of 8 lines (one text line = 8 ASCII art lines)
+// For ASCII art, each group of height lines represents one line of text
+func JustifyAlign(lines []string, termWidth int, height int) []string {
+	// Justify works on groups of height lines (one text line = height ASCII art lines)
 	// We need to process each group separately
+	if height <= 0 {
+		return CenterAlign(lines, termWidth)
+	}
 
 	result := make([]string, 0, len(lines))
 
-	// Process in chunks of 8 lines
-	for i := 0; i < len(lines); i += 8 {
-		end := i + 8
+	// Process in chunks of height lines
+	for i := 0; i < len(lines); i += height {
+		end := i + height
 		if end > len(lines) {
 			end = len(lines)
 		}
@@ -77,9 +82,7 @@ func JustifyAlign(lines []string, termWidth int) []string {
 	return result
 }
 
-// JustifyChunk justifies a single 8-line chunk (one line of text)
-// This extracts individual words and distributes them evenly across terminal width
-// JustifyChunk justifies a single 8-line chunk (one line of text)
+// JustifyChunk justifies a single chunk of glyph rows (one line of text)
 // This extracts individual words and distributes them evenly across terminal width
 func JustifyChunk(chunk []string, termWidth int) []string {
 	// ========================================
@@ -91,8 +94,8 @@ func JustifyChunk(chunk []string, termWidth int) []string {
 	)
 	// ========================================
 
-	if len(chunk) != 8 {
-		return CenterAlign(chunk, termWidth)
+	if len(chunk) == 0 {
+		return chunk
 	}
 
 	// Step 1: Extract individual words from the ASCII art chunk
@@ -142,8 +145,8 @@ func JustifyChunk(chunk []string, termWidth int) []string {
 	extraSpace := availableSpace % gaps
 
 	// Step 6: Build justified output by combining words with calculated spacing
-	result := make([]string, 8)
-	for row := 0; row < 8; row++ {
+	result := make([]string, len(chunk))
+	for row := range chunk {
 		// Start with left margin
 		line := strings.Repeat(" ", margin)
 
@@ -169,18 +172,21 @@ func JustifyChunk(chunk []string, termWidth int) []string {
 	return result
 }
 
-// extractWords splits an 8-line ASCII art chunk into individual words
-// Returns a slice of words, where each word is represented as 8 lines
+// extractWords splits an ASCII art chunk into individual words
+// Returns a slice of words, where each word is represented as len(chunk) lines
 func extractWords(chunk []string) [][]string {
-	if len(chunk) != 8 {
+	height := len(chunk)
+	if height == 0 {
 		return nil
 	}
 
-	// Find the maximum width
+	// Work on runes so multi-byte glyph ink is one column wide
+	paddedChunk := make([][]rune, height)
 	maxWidth := 0
-	for _, line := range chunk {
-		if len(line) > maxWidth {
-			maxWidth = len(line)
+	for i, line := range chunk {
+		paddedChunk[i] = []rune(line)
+		if len(paddedChunk[i]) > maxWidth {
+			maxWidth = len(paddedChunk[i])
 		}
 	}
 
@@ -189,25 +195,22 @@ func extractWords(chunk []string) [][]string {
 	}
 
 	// Pad all lines to same width with spaces
-	paddedChunk := make([]string, 8)
-	for i, line := range chunk {
-		if len(line) < maxWidth {
-			paddedChunk[i] = line + strings.Repeat(" ", maxWidth-len(line))
-		} else {
-			paddedChunk[i] = line
+	for i := range paddedChunk {
+		for len(paddedChunk[i]) < maxWidth {
+			paddedChunk[i] = append(paddedChunk[i], ' ')
 		}
 	}
 
 	// Detect word boundaries by finding columns that are all spaces
 	words := make([][]string, 0)
-	currentWord := make([]string, 8)
+	currentWord := make([]string, height)
 	inWord := false
 	consecutiveSpaces := 0
 
 	for col := 0; col < maxWidth; col++ {
-		// Check if this column is all spaces across all 8 rows
+		// Check if this column is all spaces across all rows
 		allSpaces := true
-		for row := 0; row < 8; row++ {
+		for row := 0; row < height; row++ {
 			if col < len(paddedChunk[row]) && paddedChunk[row][col] != ' ' {
 				allSpaces = false
 				break
@@ -219,21 +222,21 @@ func extractWords(chunk []string) [][]string {
 			// If we have 2+ consecutive space columns, it's a word boundary
 			if consecutiveSpaces >= 2 && inWord {
 				// Trim trailing spaces from current word
-				trimmedWord := make([]string, 8)
-				for i := 0; i < 8; i++ {
+				trimmedWord := make([]string, height)
+				for i := 0; i < height; i++ {
 					trimmedWord[i] = strings.TrimRight(currentWord[i], " ")
 				}
 				words = append(words, trimmedWord)
 
 				// Reset for next word
-				currentWord = make([]string, 8)
+				currentWord = make([]string, height)
 				inWord = false
 			}
 		} else {
 			consecutiveSpaces = 0
 			inWord = true
 			// Add this column to current word
-			for row := 0; row < 8; row++ {
+			for row := 0; row < height; row++ {
 				if col < len(paddedChunk[row]) {
 					currentWord[row] += string(paddedChunk[row][col])
 				}
@@ -243,8 +246,8 @@ func extractWords(chunk []string) [][]string {
 
 	// Don't forget the last word
 	if inWord {
-		trimmedWord := make([]string, 8)
-		for i := 0; i < 8; i++ {
+		trimmedWord := make([]string, height)
+		for i := 0; i < height; i++ {
 			trimmedWord[i] = strings.TrimRight(currentWord[i], " ")
 		}
 		words = append(words, trimmedWord)
@@ -253,18 +256,18 @@ func extractWords(chunk []string) [][]string {
 	return words
 }
 
-// getWordWidth returns the width of a word (length of its first line)
+// getWordWidth returns the width of a word (width of its first line)
 func getWordWidth(word []string) int {
 	if len(word) == 0 {
 		return 0
 	}
-	return len(word[0])
+	return GetLineWidth(word[0])
 }
 
 // AlignLine applies alignment to a single line of text
 // This is a helper function for simpler alignment scenarios
 func AlignLine(line string, alignType string, termWidth int) string {
-	lineWidth := len(line)
+	lineWidth := GetLineWidth(line)
 	if lineWidth >= termWidth {
 		return line
 	}
@@ -282,3 +285,34 @@ func AlignLine(line string, alignType string, termWidth int) string {
 		return line
 	}
 }
+
+// centerRows centers each row of cells like CenterAlign, padding with blank cells that come from no input
+func centerRows(rows [][]ascii.Cell, termWidth int) [][]ascii.Cell {
+	result := make([][]ascii.Cell, len(rows))
+	for i, row := range rows {
+		if len(row) >= termWidth {
+			result[i] = row
+			continue
+		}
+		result[i] = append(BlankCells((termWidth-len(row))/2), row...)
+	}
+	return result
+}
+
+// fillRows pads rows with blank cells to termWidth columns, so a border around them spans the whole width
+func fillRows(rows [][]ascii.Cell, termWidth int) [][]ascii.Cell {
+	result := make([][]ascii.Cell, len(rows))
+	for i, row := range rows {
+		result[i] = append(row[:len(row):len(row)], BlankCells(termWidth-len(row))...)
+	}
+	return result
+}
+
+// BlankCells returns n spaces that come from no input character, none when n is not positive
+func BlankCells(n int) []ascii.Cell {
+	cells := make([]ascii.Cell, max(n, 0))
+	for i := range cells {
+		cells[i] = ascii.Cell{Ch: ' ', Src: -1}
+	}
+	return cells
+}
@@ -1,160 +1,186 @@
 package asciijustify
 
 import (
-	"bytes"
-	"fmt"
+	"ascii-art/internal/ascii"
 	"io"
-	"os"
 	"strings"
+	"unicode"
 )
 
-// HandleJustify orchestrates the justify alignment feature
-// For justify, it renders words separately. For other alignments, it captures output.
-func HandleJustify(renderFunc func(), alignType string, input string, banner map[rune][]string) error {
-	// Special handling for justify - render words separately
-	if alignType == "justify" {
-		termWidth := GetTerminalWidth()
-		justifiedLines := RenderWithJustify(input, banner, termWidth)
-		for _, line := range justifiedLines {
-			fmt.Println(line)
-		}
-		return nil
-	}
-
-	// For left alignment, just render normally
-	if alignType == "left" {
-		renderFunc()
-		return nil
-	}
-
-	// For center and right, capture output and apply alignment
-	// Save original stdout
-	oldStdout := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
-		return err
-	}
-	os.Stdout = w
-
-	// Channel to capture output
-	outputChan := make(chan string)
-	go func() {
-		var buf bytes.Buffer
-		io.Copy(&buf, r)
-		outputChan <- buf.String()
-	}()
-
-	// Execute render function
-	renderFunc()
-
-	// Close writer and restore stdout
-	w.Close()
-	os.Stdout = oldStdout
-
-	// Get captured output
-	output := <-outputChan
-
-	// Split output into lines
-	lines := strings.Split(output, "\n")
+// HandleJustify orchestrates the justify alignment feature and writes the result to w
+// For justify, it renders words separately. For other alignments, it aligns the rendered lines.
+// Lines are aligned within opts.Width when it is set, otherwise within the terminal width.
+func HandleJustify(w io.Writer, lines []string, alignType string, input string, font *ascii.Font, opts ascii.Options) error {
+	alignedLines := AlignRendered(lines, alignType, input, font, opts, AlignWidth(opts.Width))
+	return ascii.WriteLines(w, alignedLines)
+}
 
-	// Remove trailing empty line if exists
-	if len(lines) > 0 && lines[len(lines)-1] == "" {
-		lines = lines[:len(lines)-1]
+// AlignWidth returns the width art is aligned within: width when it is set, otherwise the terminal width
+func AlignWidth(width int) int {
+	if width <= 0 {
+		return GetTerminalWidth()
 	}
+	return width
+}
 
-	if len(lines) == 0 {
-		return nil
+// AlignRendered applies alignType to already-rendered lines and returns the result
+// Justify needs the original input, font and options because it re-renders each word
+func AlignRendered(lines []string, alignType string, input string, font *ascii.Font, opts ascii.Options, termWidth int) []string {
+	// Special handling for justify - render words separately
+	if alignType == "justify" {
+		return RenderWithJustify(input, font, opts, termWidth)
 	}
 
-	// Get terminal width
-	termWidth := GetTerminalWidth()
-
-	// Apply alignment
-	alignedLines := ApplyAlignment(lines, alignType, termWidth)
-
-	// Print aligned output
-	for _, line := range alignedLines {
-		fmt.Println(line)
+	// For left alignment, keep lines as they are
+	if alignType == "left" || len(lines) == 0 {
+		return lines
 	}
 
-	return nil
+	// For center and right, apply alignment to the rendered lines
+	return ApplyAlignment(lines, alignType, termWidth, font.Height)
 }
 
 // RenderWithJustify renders text with justify alignment by rendering words separately
 // This is necessary because justify needs to control spacing between words
-func RenderWithJustify(input string, banner map[rune][]string, termWidth int) []string {
-	result := make([]string, 0)
+func RenderWithJustify(input string, font *ascii.Font, opts ascii.Options, termWidth int) []string {
+	return JustifyCanvas(input, 0, font, opts, termWidth).Lines()
+}
+
+// JustifyCanvas renders text justified within termWidth, like RenderWithJustify
+// Sources count from offset in the whole input, so lines justified one at a time are colored like the whole text.
+// A transform, effect, inversion, shadow and border in opts are applied to the justified block, not to each word
+// Vertical text has no words side by side to spread out, so its columns are centred instead
+func JustifyCanvas(input string, offset int, font *ascii.Font, opts ascii.Options, termWidth int) *ascii.Canvas {
+	if opts.Direction == ascii.DirectionVertical {
+		rows := ascii.Render(input, font, opts).Rows
+		shiftSources(rows, offset, len(input))
+		return &ascii.Canvas{Rows: centerRows(rows, termWidth)}
+	}
+
+	// Words are spread out inside the shadow and border, which then go around the whole block
+	finish := opts
+	fullWidth := termWidth
+	opts.Transform, opts.Effect, opts.Invert = ascii.TransformNone, ascii.Effect{}, 0
+	opts.Shadow, opts.Border = ascii.Shadow{}, ascii.Border{}
+	opts.Width = finish.InnerWidth(opts.Width)
+	termWidth = finish.InnerWidth(termWidth)
+	_, sy := opts.Scale()
 
 	// Handle empty input or only newlines
 	if len(strings.ReplaceAll(input, "\n", "")) == 0 {
 		lineCount := len(strings.Split(input, "\n")) - 1
-		for i := 0; i < lineCount; i++ {
-			result = append(result, "")
-		}
-		return result
+		return FinishJustified(&ascii.Canvas{Rows: make([][]ascii.Cell, lineCount*sy)}, finish, fullWidth)
 	}
 
 	// Split input by newlines
 	inputLines := strings.Split(input, "\n")
+	blocks := make([]*ascii.Canvas, 0, len(inputLines))
 
-	for _, line := range inputLines {
-		if line == "" {
-			result = append(result, "")
-			continue
-		}
+	for _, inputLine := range inputLines {
+		// Long lines are wrapped the same way as the renderer wraps them
+		for _, seg := range ascii.WrapSegments(inputLine, font, opts) {
+			words := splitWords(seg.Text)
 
-		// Split line into words
-		words := strings.Fields(line) // Fields splits by whitespace
+			// An empty line is one empty row, repeated by the vertical scale
+			if len(words) == 0 {
+				blocks = append(blocks, &ascii.Canvas{Rows: make([][]ascii.Cell, sy)})
+				continue
+			}
 
-		if len(words) == 0 {
-			result = append(result, "")
-			continue
-		}
+			// Render each word separately; a hyphen added by wrapping goes after the last one
+			renderedWords := make([][][]ascii.Cell, len(words))
+			for i, word := range words {
+				hyphen := seg.Hyphen && i == len(words)-1
+				renderedWords[i] = renderWord(word.text, offset+seg.Offset+word.offset, hyphen, font, opts)
+			}
 
-		// Render each word separately
-		renderedWords := make([][]string, len(words))
-		for i, word := range words {
-			renderedWords[i] = renderWord(word, banner)
+			// Apply justify spacing between rendered words
+			// Words are kept at least as far apart as the letters in them
+			blocks = append(blocks, &ascii.Canvas{Rows: justifyRenderedWords(renderedWords, termWidth, max(opts.LetterSpacing, 0))})
 		}
-
-		// Apply justify spacing between rendered words
-		justifiedLines := justifyRenderedWords(renderedWords, termWidth)
-		result = append(result, justifiedLines...)
+		offset += len(inputLine) + 1
 	}
 
-	return result
+	// Stack the justified lines of text with the same vertical layout as the renderer
+	return FinishJustified(ascii.StackBlocks(blocks, font, opts), finish, fullWidth)
 }
 
-// renderWord renders a single word as ASCII art
-func renderWord(word string, banner map[rune][]string) []string {
-	result := make([]string, 8)
+// FinishJustified applies the transform, effect, inversion, shadow and border of opts to a justified block
+// The block is widened to the border first, so the frame spans termWidth columns.
+func FinishJustified(canvas *ascii.Canvas, opts ascii.Options, termWidth int) *ascii.Canvas {
+	border := opts.Border
+	opts.Border = ascii.Border{}
+	canvas = opts.Finish(canvas)
+	if !border.IsZero() {
+		canvas = &ascii.Canvas{Rows: fillRows(canvas.Rows, border.Inner(termWidth))}
+	}
+	return border.Apply(canvas)
+}
 
-	for _, ch := range word {
-		charArt, ok := banner[ch]
-		if !ok {
-			continue
-		}
+// word is a word of a line of text and its byte offset in the line
+type word struct {
+	text   string
+	offset int
+}
 
-		// Append each line of the character to the result
-		for i := 0; i < 8; i++ {
-			if i < len(charArt) {
-				result[i] += charArt[i]
+// splitWords splits line at whitespace like strings.Fields, keeping where each word starts
+func splitWords(line string) []word {
+	var words []word
+	start := -1
+	for i, r := range line {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				words = append(words, word{text: line[start:i], offset: start})
+				start = -1
 			}
+		} else if start < 0 {
+			start = i
 		}
 	}
+	if start >= 0 {
+		words = append(words, word{text: line[start:], offset: start})
+	}
+	return words
+}
 
-	return result
+// renderWord renders a single word as ASCII art, its sources counted from offset
+// Characters missing from the font follow the same fallback policy as the renderer
+// A hyphen drawn after the word comes from no input character
+func renderWord(text string, offset int, hyphen bool, font *ascii.Font, opts ascii.Options) [][]ascii.Cell {
+	size := len(text)
+	if hyphen {
+		text += "-"
+	}
+	rows := ascii.Render(text, font, opts).Rows
+	shiftSources(rows, offset, size)
+	return rows
+}
+
+// shiftSources moves the sources of cells drawn by the first size bytes of a text on by offset
+// Cells drawn past them, such as a hyphen, get no source.
+func shiftSources(rows [][]ascii.Cell, offset, size int) {
+	for _, row := range rows {
+		for i := range row {
+			switch src := row[i].Src; {
+			case src >= size:
+				row[i].Src = -1
+			case src >= 0:
+				row[i].Src = src + offset
+			}
+		}
+	}
 }
 
 // justifyRenderedWords applies justify spacing between already-rendered words
-func justifyRenderedWords(renderedWords [][]string, termWidth int) []string {
+// Words are never closer than minGap columns
+func justifyRenderedWords(renderedWords [][][]ascii.Cell, termWidth int, minGap int) [][]ascii.Cell {
 	if len(renderedWords) == 0 {
-		return []string{}
+		return nil
 	}
 
 	// If only one word, center it
 	if len(renderedWords) == 1 {
-		return CenterAlign(renderedWords[0], termWidth)
+		return centerRows(renderedWords[0], termWidth)
 	}
 
 	// Calculate total width of all words
@@ -173,10 +199,10 @@ func justifyRenderedWords(renderedWords [][]string, termWidth int) []string {
 	usableWidth := termWidth - (2 * margin)
 
 	// Check if content fits
-	if totalWordWidth >= usableWidth {
+	if totalWordWidth+minGap*(len(renderedWords)-1) >= usableWidth {
 		// Too wide, center instead
-		combined := combineWords(renderedWords, 0)
-		return CenterAlign(combined, termWidth)
+		combined := combineWords(renderedWords, minGap)
+		return centerRows(combined, termWidth)
 	}
 
 	// Calculate spacing
@@ -184,8 +210,8 @@ func justifyRenderedWords(renderedWords [][]string, termWidth int) []string {
 	gaps := len(renderedWords) - 1
 
 	if gaps <= 0 || availableSpace <= 0 {
-		combined := combineWords(renderedWords, 0)
-		return CenterAlign(combined, termWidth)
+		combined := combineWords(renderedWords, minGap)
+		return centerRows(combined, termWidth)
 	}
 
 	spacePerGap := availableSpace / gaps
@@ -193,15 +219,16 @@ func justifyRenderedWords(renderedWords [][]string, termWidth int) []string {
 	extraSpace := availableSpace % gaps
 
 	// Build justified output
-	result := make([]string, 8)
-	for row := 0; row < 8; row++ {
+	height := wordsHeight(renderedWords)
+	result := make([][]ascii.Cell, height)
+	for row := 0; row < height; row++ {
 		// Start with left margin
-		line := strings.Repeat(" ", margin)
+		line := BlankCells(margin)
 
 		for wordIdx, word := range renderedWords {
 			// Add the word
 			if row < len(word) {
-				line += word[row]
+				line = append(line, word[row]...)
 			}
 
 			// Add spacing after word (except last word)
@@ -210,7 +237,7 @@ func justifyRenderedWords(renderedWords [][]string, termWidth int) []string {
 				if wordIdx < extraSpace {
 					spacing++
 				}
-				line += strings.Repeat(" ", spacing)
+				line = append(line, BlankCells(spacing)...)
 			}
 		}
 		result[row] = line
@@ -220,25 +247,36 @@ func justifyRenderedWords(renderedWords [][]string, termWidth int) []string {
 }
 
 // combineWords combines rendered words with fixed spacing between them
-func combineWords(renderedWords [][]string, spacing int) []string {
+func combineWords(renderedWords [][][]ascii.Cell, spacing int) [][]ascii.Cell {
 	if len(renderedWords) == 0 {
-		return []string{}
+		return nil
 	}
 
-	result := make([]string, 8)
-	spacer := strings.Repeat(" ", spacing)
+	height := wordsHeight(renderedWords)
+	result := make([][]ascii.Cell, height)
 
-	for row := 0; row < 8; row++ {
+	for row := 0; row < height; row++ {
 		for wordIdx, word := range renderedWords {
 			if row < len(word) {
-				result[row] += word[row]
+				result[row] = append(result[row], word[row]...)
 			}
 			// Add spacing between words (except after last word)
 			if wordIdx < len(renderedWords)-1 {
-				result[row] += spacer
+				result[row] = append(result[row], BlankCells(spacing)...)
 			}
 		}
 	}
 
 	return result
 }
+
+// wordsHeight returns the number of rows in the tallest rendered word
+func wordsHeight(renderedWords [][][]ascii.Cell) int {
+	height := 0
+	for _, word := range renderedWords {
+		if len(word) > height {
+			height = len(word)
+		}
+	}
+	return height
+}
@@ -1,15 +1,16 @@
 package asciijustify
 
 import (
-	"strings"
+	"ascii-art/internal/ascii"
+	"unicode/utf8"
 )
 
 // GetArtWidth returns the width of the widest line in ASCII art
 func GetArtWidth(asciiLines []string) int {
 	maxWidth := 0
 	for _, line := range asciiLines {
-		if len(line) > maxWidth {
-			maxWidth = len(line)
+		if width := GetLineWidth(line); width > maxWidth {
+			maxWidth = width
 		}
 	}
 	return maxWidth
@@ -21,39 +22,34 @@ func GetArtHeight(asciiLines []string) int {
 }
 
 // MeasureText measures dimensions of text as it would be rendered in ASCII art
+// The text is laid out with the same layout as the renderer, so fitted and smushed widths are exact
 // Returns: width, height
-func MeasureText(input string, banner map[rune][]string) (int, int) {
-	lines := strings.Split(input, "\n")
-	maxWidth := 0
-	totalHeight := 0
-
-	for _, line := range lines {
-		if line == "" {
-			totalHeight += 1
-			continue
-		}
-
-		// Calculate width of this line
-		lineWidth := 0
-		for _, ch := range line {
-			if charArt, ok := banner[ch]; ok {
-				lineWidth += GetArtLineWidth(charArt)
-			}
-		}
-
-		if lineWidth > maxWidth {
-			maxWidth = lineWidth
-		}
-		totalHeight += 8 // Each line of text is 8 rows in ASCII art
-	}
-
-	return maxWidth, totalHeight
+func MeasureText(input string, font *ascii.Font, opts ascii.Options) (int, int) {
+	canvas := ascii.Render(input, font, opts)
+	return canvas.Width(), canvas.Height()
 }
 
 // GetLineWidth calculates the width of a single ASCII art line
 // This is a helper for measuring already-rendered ASCII art
+// Multi-byte characters count as one column and ANSI color codes take no space
 func GetLineWidth(line string) int {
-	return len(line)
+	width := 0
+	for i := 0; i < len(line); {
+		// Skip escape sequences such as "\033[31m"
+		if line[i] == '\033' && i+1 < len(line) && line[i+1] == '[' {
+			i += 2
+			for i < len(line) && (line[i] < 0x40 || line[i] > 0x7e) {
+				i++
+			}
+			i++
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		width++
+	}
+	return width
 }
 
 // GetMaxLineWidth returns the width of the longest line in rendered ASCII art
@@ -46,6 +46,19 @@ func GetTerminalWidth() int {
 	return 80
 }
 
+// IsTerminal reports whether stdout is a terminal
+// Output that is piped or redirected is not wrapped to a terminal width
+func IsTerminal() bool {
+	ws := &winsize{}
+
+	retCode, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
+		uintptr(syscall.Stdout),
+		uintptr(syscall.TIOCGWINSZ),
+		uintptr(unsafe.Pointer(ws)))
+
+	return int(retCode) != -1 && errno == 0
+}
+
 // FitsInTerminal checks if the given width fits in terminal
 func FitsInTerminal(contentWidth int) bool {
 	termWidth := GetTerminalWidth()
@@ -53,12 +66,12 @@ func FitsInTerminal(contentWidth int) bool {
 }
 
 // GetArtLineWidth returns the width of a single line of ASCII art
-// Returns the length of the first line (all lines should be same width)
+// Returns the width of the first line (all lines should be same width)
 func GetArtLineWidth(charArt []string) int {
 	if len(charArt) == 0 {
 		return 0
 	}
-	return len(charArt[0])
+	return GetLineWidth(charArt[0])
 }
 
 // CalculatePadding calculates the padding needed for alignment
@@ -25,6 +25,16 @@ func WriteToFile(filename, content string) error {
 	return nil
 }
 
+// CreateFile creates or truncates the specified file for output that is written as it is produced
+// The caller must close the returned file
+func CreateFile(filename string) (*os.File, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, WrapFileCreateError(filename, err)
+	}
+	return file, nil
+}
+
 // FileExists checks if a file exists at the given path
 func FileExists(filename string) bool {
 	_, err := os.Stat(filename)
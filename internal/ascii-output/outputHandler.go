@@ -1,33 +1,29 @@
 package asciioutput
 
 import (
-	"bytes"
-	"fmt"
 	"io"
 	"os"
+	"strings"
 )
 
-// RenderFunc is a function type that renders ASCII art to stdout
-type RenderFunc func()
+// RenderFunc is a function type that renders ASCII art to the given writer
+type RenderFunc func(w io.Writer)
 
 // HandleOutput manages output routing - either to file or stdout
 // If outputFile is empty, renders directly to stdout
-// If outputFile is provided, captures stdout and writes to file
+// If outputFile is provided, captures the rendered output and writes it to file
 func HandleOutput(outputFile string, renderFunc RenderFunc) error {
 	if outputFile == "" {
 		// No output file specified, render directly to stdout
-		renderFunc()
+		renderFunc(os.Stdout)
 		return nil
 	}
 
 	// Capture output and write to file
-	output, err := CaptureStdout(renderFunc)
-	if err != nil {
-		return fmt.Errorf("failed to capture output: %w", err)
-	}
+	output := CaptureOutput(renderFunc)
 
 	// Write captured output to file
-	err = WriteToFile(outputFile, output)
+	err := WriteToFile(outputFile, output)
 	if err != nil {
 		return err
 	}
@@ -35,33 +31,10 @@ func HandleOutput(outputFile string, renderFunc RenderFunc) error {
 	return nil
 }
 
-// CaptureStdout redirects stdout, executes the function, and returns captured output
-func CaptureStdout(fn RenderFunc) (string, error) {
-	// Save original stdout
-	old := os.Stdout
-
-	// Create pipe to capture output
-	r, w, err := os.Pipe()
-	if err != nil {
-		return "", fmt.Errorf("failed to create pipe: %w", err)
-	}
-
-	// Redirect stdout to pipe writer
-	os.Stdout = w
-
-	// Execute the render function
-	fn()
-
-	// Close writer and restore stdout
-	w.Close()
-	os.Stdout = old
-
-	// Read captured output from pipe
-	var buf bytes.Buffer
-	_, err = io.Copy(&buf, r)
-	if err != nil {
-		return "", fmt.Errorf("failed to read captured output: %w", err)
-	}
-
-	return buf.String(), nil
+// CaptureOutput executes the render function against an in-memory buffer and returns what it wrote
+// Unlike redirecting os.Stdout, this is safe to call from multiple goroutines
+func CaptureOutput(fn RenderFunc) string {
+	var sb strings.Builder
+	fn(&sb)
+	return sb.String()
 }
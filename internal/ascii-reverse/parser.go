@@ -1,13 +1,26 @@
 package asciireverse
 
 import (
+	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
-// ParseAsciiArt parses ASCII art content into chunks of 8 lines
+// ParseAsciiArt parses ASCII art content into chunks of height lines
 // Each chunk represents one line of text in the original message
-// Returns a slice of chunks, where each chunk is 8 lines of ASCII art
-func ParseAsciiArt(content string) ([][]string, error) {
+// Returns a slice of chunks, where each chunk is height lines of ASCII art
+func ParseAsciiArt(content string, height int) ([][]string, error) {
+	return ParseSpacedAsciiArt(content, height, 0)
+}
+
+// ParseSpacedAsciiArt parses ASCII art rendered with --line-spacing into chunks of height lines
+// The lineSpacing blank rows between lines of text are dropped; they are never empty,
+// unlike the row of an empty line of text.
+func ParseSpacedAsciiArt(content string, height, lineSpacing int) ([][]string, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("invalid glyph height %d", height)
+	}
+
 	// Split content into lines
 	lines := strings.Split(content, "\n")
 
@@ -18,11 +31,31 @@ func ParseAsciiArt(content string) ([][]string, error) {
 
 	var chunks [][]string
 
-	// Process lines in groups of 8 (each character is 8 lines tall)
-	for i := 0; i < len(lines); i += 8 {
-		// Get 8 lines for this chunk
-		chunk := make([]string, 8)
-		for j := 0; j < 8; j++ {
+	// Process lines in groups of height (each character is height lines tall)
+	for i := 0; i < len(lines); {
+		// Rows spacing the previous line of text from this one
+		if len(chunks) > 0 {
+			for gap := 0; gap < lineSpacing && i < len(lines); gap++ {
+				if !isSpacingRow(lines[i]) {
+					return nil, fmt.Errorf("expected %d blank rows between lines of text at row %d", lineSpacing, i)
+				}
+				i++
+			}
+			if i == len(lines) {
+				break
+			}
+		}
+
+		// An empty line of text is rendered as a single empty row
+		if lines[i] == "" {
+			chunks = append(chunks, make([]string, height))
+			i++
+			continue
+		}
+
+		// Get height lines for this chunk
+		chunk := make([]string, height)
+		for j := 0; j < height; j++ {
 			if i+j < len(lines) {
 				chunk[j] = lines[i+j]
 			} else {
@@ -32,25 +65,26 @@ func ParseAsciiArt(content string) ([][]string, error) {
 		}
 
 		chunks = append(chunks, chunk)
+		i += height
 	}
 
 	return chunks, nil
 }
 
-// SplitChunkIntoCharacters splits a chunk (8 lines) into individual character patterns
-// Returns a slice of character patterns, where each pattern is 8 lines
-func SplitChunkIntoCharacters(chunk []string, charWidth int) [][]string {
-	if len(chunk) != 8 {
+// isSpacingRow reports whether line is a row added by line spacing: blank, but not empty
+func isSpacingRow(line string) bool {
+	return line != "" && strings.TrimSpace(line) == ""
+}
+
+// SplitChunkIntoCharacters splits a chunk (height lines) into individual character patterns
+// Returns a slice of character patterns, where each pattern is height lines
+func SplitChunkIntoCharacters(chunk []string, charWidth int, height int) [][]string {
+	if len(chunk) != height || height == 0 {
 		return nil
 	}
 
-	// Find the maximum line length
-	maxLen := 0
-	for _, line := range chunk {
-		if len(line) > maxLen {
-			maxLen = len(line)
-		}
-	}
+	// Find the maximum line width
+	maxLen := maxColumns(chunk)
 
 	// If maxLen is 0, return empty
 	if maxLen == 0 {
@@ -63,24 +97,9 @@ func SplitChunkIntoCharacters(chunk []string, charWidth int) [][]string {
 	pos := 0
 	for pos < maxLen {
 		// Extract character pattern at current position
-		pattern := make([]string, 8)
-		endPos := pos + charWidth
-
-		for i := 0; i < 8; i++ {
-			if pos < len(chunk[i]) {
-				if endPos <= len(chunk[i]) {
-					pattern[i] = chunk[i][pos:endPos]
-				} else {
-					// Pad with spaces if line is too short
-					pattern[i] = chunk[i][pos:]
-					for len(pattern[i]) < charWidth {
-						pattern[i] += " "
-					}
-				}
-			} else {
-				// Line doesn't reach this position, fill with spaces
-				pattern[i] = strings.Repeat(" ", charWidth)
-			}
+		pattern := make([]string, height)
+		for i := 0; i < height; i++ {
+			pattern[i] = columnSlice(chunk[i], pos, charWidth)
 		}
 
 		characters = append(characters, pattern)
@@ -90,26 +109,49 @@ func SplitChunkIntoCharacters(chunk []string, charWidth int) [][]string {
 	return characters
 }
 
-// NormalizePattern ensures all lines in a pattern have the same length
+// columnSlice returns width columns of line starting at column pos
+// Columns count runes, and the result is padded with spaces when the line is too short
+func columnSlice(line string, pos, width int) string {
+	runes := []rune(line)
+	var sb strings.Builder
+
+	for col := pos; col < pos+width; col++ {
+		if col < len(runes) {
+			sb.WriteRune(runes[col])
+		} else {
+			sb.WriteByte(' ')
+		}
+	}
+
+	return sb.String()
+}
+
+// maxColumns returns the width in runes of the widest line
+func maxColumns(lines []string) int {
+	maxLen := 0
+	for _, line := range lines {
+		if n := utf8.RuneCountInString(line); n > maxLen {
+			maxLen = n
+		}
+	}
+	return maxLen
+}
+
+// NormalizePattern ensures all lines in a pattern have the same width
 // Pads shorter lines with spaces
 func NormalizePattern(pattern []string) []string {
 	if len(pattern) == 0 {
 		return pattern
 	}
 
-	// Find maximum length
-	maxLen := 0
-	for _, line := range pattern {
-		if len(line) > maxLen {
-			maxLen = len(line)
-		}
-	}
+	// Find maximum width
+	maxLen := maxColumns(pattern)
 
 	// Normalize all lines to maxLen
 	normalized := make([]string, len(pattern))
 	for i, line := range pattern {
-		if len(line) < maxLen {
-			normalized[i] = line + strings.Repeat(" ", maxLen-len(line))
+		if n := utf8.RuneCountInString(line); n < maxLen {
+			normalized[i] = line + strings.Repeat(" ", maxLen-n)
 		} else {
 			normalized[i] = line
 		}
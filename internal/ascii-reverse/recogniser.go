@@ -1,34 +1,46 @@
 package asciireverse
 
 import (
+	"ascii-art/internal/ascii"
 	"fmt"
 	"strings"
 )
 
-// RecogniseText takes ASCII art chunks and banner templates, and returns the recognized text
-// Handles variable-width characters by trying to match each character from the templates
-func RecogniseText(chunks [][]string, templates map[rune][]string) (string, error) {
+// RecogniseText takes ASCII art chunks and a banner font, and returns the recognized text
+// Handles variable-width characters by trying to match each character from the font
+func RecogniseText(chunks [][]string, font *ascii.Font) (string, error) {
+	return RecogniseSpacedText(chunks, font, 0)
+}
+
+// RecogniseSpacedText recognizes text in chunks rendered with --letter-spacing=spacing
+// Positive spacing leaves blank columns between characters; negative spacing overlaps them
+func RecogniseSpacedText(chunks [][]string, font *ascii.Font, spacing int) (string, error) {
 	var result strings.Builder
+	height := font.Height
 
 	// Process each chunk (each chunk is one line of text)
 	for chunkIdx, chunk := range chunks {
-		if len(chunk) != 8 {
-			return "", fmt.Errorf("invalid chunk at index %d: expected 8 lines, got %d", chunkIdx, len(chunk))
+		if len(chunk) != height {
+			return "", fmt.Errorf("invalid chunk at index %d: expected %d lines, got %d", chunkIdx, height, len(chunk))
 		}
 
-		// Find the maximum line length in the chunk
-		maxLen := 0
-		for _, line := range chunk {
-			if len(line) > maxLen {
-				maxLen = len(line)
-			}
-		}
+		// Find the maximum line width in the chunk
+		maxLen := maxColumns(chunk)
 
-		// If chunk is empty, skip it
-		if maxLen == 0 {
+		// Overlapping characters can't be matched one after another, see recogniseOverlapped
+		if spacing < 0 {
+			text, err := recogniseOverlapped(chunk, font, -spacing)
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(text)
+			if chunkIdx < len(chunks)-1 {
+				result.WriteRune('\n')
+			}
 			continue
 		}
 
+		// An empty chunk is an empty line of text, so only the newline is kept
 		// Process characters by trying to match at each position
 		pos := 0
 		for pos < maxLen {
@@ -37,8 +49,9 @@ func RecogniseText(chunks [][]string, templates map[rune][]string) (string, erro
 			var matchedChar rune
 			matchLen := 0
 
-			// Try all characters from templates
-			for char, template := range templates {
+			// Try all characters from the font
+			for _, char := range font.Runes() {
+				template := font.Glyphs[char]
 				charWidth := GetCharacterWidth(template)
 				if charWidth == 0 {
 					continue
@@ -50,22 +63,9 @@ func RecogniseText(chunks [][]string, templates map[rune][]string) (string, erro
 					continue
 				}
 
-				pattern := make([]string, 8)
-				for i := 0; i < 8; i++ {
-					if pos < len(chunk[i]) {
-						endPos := pos + charWidth
-						if endPos <= len(chunk[i]) {
-							pattern[i] = chunk[i][pos:endPos]
-						} else {
-							// Pad with spaces
-							pattern[i] = chunk[i][pos:]
-							for len(pattern[i]) < charWidth {
-								pattern[i] += " "
-							}
-						}
-					} else {
-						pattern[i] = strings.Repeat(" ", charWidth)
-					}
+				pattern := make([]string, height)
+				for i := 0; i < height; i++ {
+					pattern[i] = columnSlice(chunk[i], pos, charWidth)
 				}
 
 				// Try to match this pattern
@@ -84,6 +84,14 @@ func RecogniseText(chunks [][]string, templates map[rune][]string) (string, erro
 			if matched {
 				result.WriteRune(matchedChar)
 				pos += matchLen
+
+				// Letter spacing leaves blank columns before the next character
+				if spacing > 0 && pos < maxLen {
+					if !blankColumns(chunk, pos, spacing) {
+						return "", fmt.Errorf("expected %d blank columns after the character at position %d", spacing, pos-matchLen)
+					}
+					pos += spacing
+				}
 			} else {
 				// No match found - this is an error
 				return "", fmt.Errorf("failed to recognise character at position %d", pos)
@@ -99,14 +107,14 @@ func RecogniseText(chunks [][]string, templates map[rune][]string) (string, erro
 	return result.String(), nil
 }
 
-// RecogniseCharacter matches a pattern against templates and returns the recognized character
-func RecogniseCharacter(pattern []string, templates map[rune][]string) (rune, error) {
+// RecogniseCharacter matches a pattern against the font glyphs and returns the recognized character
+func RecogniseCharacter(pattern []string, font *ascii.Font) (rune, error) {
 	// Normalize the pattern to ensure consistent comparison
 	normalizedPattern := NormalizePattern(pattern)
 
-	// Try to match against all templates
-	for char, template := range templates {
-		normalizedTemplate := NormalizePattern(template)
+	// Try to match against all glyphs
+	for _, char := range font.Runes() {
+		normalizedTemplate := NormalizePattern(font.Glyphs[char])
 
 		if ComparePatterns(normalizedPattern, normalizedTemplate) {
 			return char, nil
@@ -119,23 +127,61 @@ func RecogniseCharacter(pattern []string, templates map[rune][]string) (rune, er
 
 // RecogniseTextWithBanner is a convenience function that loads banner content and recognizes text
 func RecogniseTextWithBanner(asciiArt string, bannerContent string) (string, error) {
-	// Parse ASCII art into chunks
-	chunks, err := ParseAsciiArt(asciiArt)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse ASCII art: %w", err)
-	}
-
 	// Load banner templates
-	templates, err := LoadBannerTemplates(bannerContent)
+	font, err := LoadBannerTemplates(bannerContent)
 	if err != nil {
 		return "", fmt.Errorf("failed to load banner templates: %w", err)
 	}
 
-	// Recognise text
-	text, err := RecogniseText(chunks, templates)
-	if err != nil {
-		return "", fmt.Errorf("failed to recognise text: %w", err)
+	return RecogniseTextWithFont(asciiArt, font)
+}
+
+// RecogniseTextWithFont recognizes text in asciiArt using an already loaded font
+// Art rendered with --scale is detected and scaled back down before it is matched
+func RecogniseTextWithFont(asciiArt string, font *ascii.Font) (string, error) {
+	// The last candidate is the art as it is, so its error is the one reported
+	var err error
+	for _, scale := range scaleCandidates(asciiArt) {
+		art := asciiArt
+		if scale != [2]int{1, 1} {
+			art = Downscale(asciiArt, scale[0], scale[1])
+		}
+
+		var text string
+		if text, err = recogniseUnscaled(art, font); err == nil {
+			return text, nil
+		}
+	}
+
+	return "", err
+}
+
+// recogniseUnscaled recognizes text in art drawn at the font's own size
+// Art rendered with --line-spacing or --letter-spacing is matched with each spacing it could have;
+// when nothing matches, the error is the one for art without spacing.
+func recogniseUnscaled(asciiArt string, font *ascii.Font) (string, error) {
+	var plainErr error
+	for _, lineSpacing := range lineSpacingCandidates(asciiArt, font.Height) {
+		// Parse ASCII art into chunks of the font's height
+		chunks, err := ParseSpacedAsciiArt(asciiArt, font.Height, lineSpacing)
+		if err != nil {
+			if lineSpacing == 0 {
+				plainErr = fmt.Errorf("failed to parse ASCII art: %w", err)
+			}
+			continue
+		}
+
+		// Recognise text
+		for _, letterSpacing := range letterSpacingCandidates(chunks, font) {
+			text, err := RecogniseSpacedText(chunks, font, letterSpacing)
+			if err == nil {
+				return text, nil
+			}
+			if lineSpacing == 0 && letterSpacing == 0 {
+				plainErr = fmt.Errorf("failed to recognise text: %w", err)
+			}
+		}
 	}
 
-	return text, nil
+	return "", plainErr
 }
@@ -1,8 +1,8 @@
 package asciireverse
 
 import (
+	"ascii-art/internal/ascii"
 	"fmt"
-	"os"
 )
 
 // HandleReverse processes the --reverse flag and converts ASCII art back to text
@@ -22,22 +22,17 @@ func HandleReverse(args []string) {
 		return
 	}
 
-	// Try all available banners to find a match
-	banners := []string{"standard", "shadow", "thinkertoy"}
-
-	for _, bannerName := range banners {
-		bannerPath := fmt.Sprintf("banners/%s.txt", bannerName)
-
-		// Load banner template
-		bannerBytes, err := os.ReadFile(bannerPath)
+	// Try every banner on the search path to find a match
+	for _, bannerName := range ascii.Banners.Names() {
+		// Load the banner from the first source that holds it
+		font, err := ascii.Banners.Load(bannerName)
 		if err != nil {
-			// Skip this banner if it can't be read
+			// Skip this banner if it can't be loaded
 			continue
 		}
-		bannerContent := string(bannerBytes)
 
 		// Try to convert ASCII art back to text with this banner
-		text, err := RecogniseTextWithBanner(asciiArtContent, bannerContent)
+		text, err := RecogniseTextWithFont(asciiArtContent, font)
 		if err == nil {
 			// Successfully recognized! Print and exit
 			fmt.Println(text)
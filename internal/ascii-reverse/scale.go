@@ -0,0 +1,84 @@
+package asciireverse
+
+import (
+	"strings"
+)
+
+// DetectScale returns the largest horizontal and vertical factors the art could have been scaled by
+// Scaled art repeats every column sx times and every row sy times, so the factors divide
+// the length of every run of equal characters in a row and of equal rows.
+func DetectScale(asciiArt string) (int, int) {
+	lines := strings.Split(strings.TrimRight(asciiArt, "\n"), "\n")
+
+	sx, sy := 0, 0
+	for i := 0; i < len(lines); {
+		// Rows repeated by vertical scaling follow each other
+		run := 1
+		for i+run < len(lines) && lines[i+run] == lines[i] {
+			run++
+		}
+		sy = gcd(sy, run)
+
+		// Characters repeated by horizontal scaling follow each other
+		runes := []rune(lines[i])
+		for j := 0; j < len(runes); {
+			width := 1
+			for j+width < len(runes) && runes[j+width] == runes[j] {
+				width++
+			}
+			sx = gcd(sx, width)
+			j += width
+		}
+
+		i += run
+	}
+
+	return max(sx, 1), max(sy, 1)
+}
+
+// Downscale undoes scaling by keeping every sx-th character of every sy-th row
+func Downscale(asciiArt string, sx, sy int) string {
+	lines := strings.Split(strings.TrimRight(asciiArt, "\n"), "\n")
+
+	var sb strings.Builder
+	for i := 0; i < len(lines); i += sy {
+		runes := []rune(lines[i])
+		for j := 0; j < len(runes); j += sx {
+			sb.WriteRune(runes[j])
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// scaleCandidates lists the scale factors to try for asciiArt, largest first, ending with 1, 1
+func scaleCandidates(asciiArt string) [][2]int {
+	sx, sy := DetectScale(asciiArt)
+
+	var candidates [][2]int
+	for _, x := range divisors(sx) {
+		for _, y := range divisors(sy) {
+			candidates = append(candidates, [2]int{x, y})
+		}
+	}
+	return candidates
+}
+
+// divisors returns the divisors of n from largest to smallest
+func divisors(n int) []int {
+	var result []int
+	for d := n; d >= 1; d-- {
+		if n%d == 0 {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// gcd returns the greatest common divisor of a and b, treating 0 as "no value yet"
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
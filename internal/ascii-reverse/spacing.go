@@ -0,0 +1,272 @@
+package asciireverse
+
+import (
+	"ascii-art/internal/ascii"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// lineSpacingCandidates lists the line spacings to try for asciiArt, largest first, ending with 0
+// The blank rows right after the first line of text bound the spacing; the next line of text
+// may start with blank rows of its own, so smaller spacings are tried too.
+func lineSpacingCandidates(asciiArt string, height int) []int {
+	lines := strings.Split(asciiArt, "\n")
+
+	first := 0
+	for first < len(lines) && lines[first] == "" {
+		first++
+	}
+
+	gap := 0
+	for i := first + height; i < len(lines) && isSpacingRow(lines[i]); i++ {
+		gap++
+	}
+	return countdown(gap, 0)
+}
+
+// letterSpacingCandidates lists the letter spacings to try for chunks, smallest gaps first, then overlaps
+// Narrow gaps come first so that a wide gap is never mistaken for a space between two gaps.
+// Gaps can be no wider than the widest run of blank columns, and glyphs overlap by less than the widest glyph.
+func letterSpacingCandidates(chunks [][]string, font *ascii.Font) []int {
+	widest := 0
+	for _, ch := range font.Runes() {
+		widest = max(widest, font.Width(ch))
+	}
+
+	var candidates []int
+	for gap := 0; gap <= widestBlankRun(chunks); gap++ {
+		candidates = append(candidates, gap)
+	}
+	for overlap := 1; overlap < widest; overlap++ {
+		candidates = append(candidates, -overlap)
+	}
+	return candidates
+}
+
+// countdown returns the numbers from high down to low
+func countdown(high, low int) []int {
+	var result []int
+	for n := high; n >= low; n-- {
+		result = append(result, n)
+	}
+	return result
+}
+
+// widestBlankRun returns the widest run of columns that are blank in every row of a chunk
+func widestBlankRun(chunks [][]string) int {
+	widest := 0
+	for _, chunk := range chunks {
+		run := 0
+		for col := 0; col < maxColumns(chunk); col++ {
+			if blankColumns(chunk, col, 1) {
+				run++
+				widest = max(widest, run)
+			} else {
+				run = 0
+			}
+		}
+	}
+	return widest
+}
+
+// blankColumns reports whether width columns of chunk from column pos are blank in every row
+func blankColumns(chunk []string, pos, width int) bool {
+	for _, line := range chunk {
+		if strings.TrimSpace(columnSlice(line, pos, width)) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// overlapTemplate is a glyph prepared for matching overlapped art
+type overlapTemplate struct {
+	char  rune
+	rows  [][]rune // Rows padded to width
+	width int
+	lead  []int // Blank columns at the start of each row, width for a blank row
+	ink   bool  // Whether any cell is not blank
+}
+
+// overlapState is the art drawn by the glyphs matched so far
+type overlapState struct {
+	rows     [][]rune // Cells drawn so far, every row end columns long
+	edge     []int    // Column of the rightmost ink in each row, -1 for none
+	end      int      // Columns drawn so far
+	verified int      // Columns before this one are final and match the chunk
+}
+
+// overlapMatcher searches for the glyphs that draw a chunk rendered with negative letter spacing
+// Each glyph slides left as far as it fits without touching the art before it, by at most overlap
+// columns, exactly like the renderer, so only the choice of glyph is searched.
+type overlapMatcher struct {
+	grid      [][]rune // Chunk rows padded to width
+	width     int
+	overlap   int
+	templates []*overlapTemplate
+	failed    map[string]bool // States known not to lead to a match
+	furthest  int             // Furthest column a glyph was tried at, for errors
+}
+
+// recogniseOverlapped recognizes the text of a chunk rendered with a letter spacing of -overlap
+func recogniseOverlapped(chunk []string, font *ascii.Font, overlap int) (string, error) {
+	m := &overlapMatcher{width: maxColumns(chunk), overlap: overlap, failed: make(map[string]bool)}
+	for _, line := range NormalizePattern(chunk) {
+		m.grid = append(m.grid, []rune(line))
+	}
+
+	for _, char := range font.Runes() {
+		pattern := NormalizePattern(font.Glyphs[char])
+		width := GetCharacterWidth(pattern)
+		if len(pattern) != len(chunk) || width == 0 {
+			continue
+		}
+
+		t := &overlapTemplate{char: char, width: width}
+		for _, line := range pattern {
+			row := []rune(line)[:width]
+			lead := 0
+			for lead < width && row[lead] == ' ' {
+				lead++
+			}
+			t.rows = append(t.rows, row)
+			t.lead = append(t.lead, lead)
+			t.ink = t.ink || lead < width
+		}
+		m.templates = append(m.templates, t)
+	}
+
+	// A blank glyph that slides far enough changes nothing, so it is only tried where no other glyph fits
+	slices.SortStableFunc(m.templates, func(a, b *overlapTemplate) int {
+		if a.ink == b.ink {
+			return 0
+		}
+		if a.ink {
+			return -1
+		}
+		return 1
+	})
+
+	if m.width == 0 {
+		return "", nil
+	}
+
+	start := overlapState{rows: make([][]rune, len(m.grid)), edge: make([]int, len(m.grid))}
+	for row := range start.edge {
+		start.edge[row] = -1
+	}
+	text, ok := m.match(start)
+	if !ok {
+		return "", fmt.Errorf("failed to recognise character at position %d", m.furthest)
+	}
+	return string(text), nil
+}
+
+// match finds the glyphs that draw the rest of the chunk after st
+func (m *overlapMatcher) match(st overlapState) ([]rune, bool) {
+	if st.end == m.width {
+		return nil, m.explained(st, st.verified, m.width)
+	}
+
+	key := st.key()
+	if m.failed[key] {
+		return nil, false
+	}
+
+	for _, t := range m.templates {
+		slide := 0
+		if st.end > 0 {
+			slide = min(m.overlap, m.fit(st, t))
+		}
+		start := st.end - slide
+
+		// A blank glyph that slides all the way in draws nothing and can't be seen
+		if (!t.ink && start+t.width <= st.end) || start+t.width > m.width || !m.inkMatches(st, t, start) {
+			continue
+		}
+		m.furthest = max(m.furthest, start)
+
+		// Later glyphs slide back at most overlap columns, so the columns before that are final
+		next := st.place(t, start)
+		next.verified = max(st.verified, next.end-m.overlap)
+		if !m.explained(next, st.verified, next.verified) {
+			continue
+		}
+
+		if rest, ok := m.match(next); ok {
+			return append([]rune{t.char}, rest...), true
+		}
+	}
+
+	m.failed[key] = true
+	return nil, false
+}
+
+// fit returns how many columns t can slide into the art drawn so far without touching it
+// This is the renderer's fitting rule: the blanks after each row's ink plus the blanks before the glyph's
+func (m *overlapMatcher) fit(st overlapState, t *overlapTemplate) int {
+	amount := t.width
+	for row := range m.grid {
+		amount = min(amount, t.lead[row]+st.end-1-st.edge[row])
+	}
+	return max(amount, 0)
+}
+
+// inkMatches reports whether every ink cell of t placed at start is in the chunk, on a blank cell of st
+func (m *overlapMatcher) inkMatches(st overlapState, t *overlapTemplate, start int) bool {
+	for row := range m.grid {
+		for i, ink := range t.rows[row] {
+			if ink == ' ' {
+				continue
+			}
+			col := start + i
+			if m.grid[row][col] != ink || (col < st.end && st.rows[row][col] != ' ') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// explained reports whether columns from to to of st are exactly the chunk
+func (m *overlapMatcher) explained(st overlapState, from, to int) bool {
+	for row := range m.grid {
+		for col := from; col < to; col++ {
+			if st.rows[row][col] != m.grid[row][col] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// place returns st with t drawn from column start
+func (st overlapState) place(t *overlapTemplate, start int) overlapState {
+	end := start + t.width
+	next := overlapState{rows: make([][]rune, len(st.rows)), edge: append([]int{}, st.edge...), end: end}
+	for row := range st.rows {
+		next.rows[row] = append(make([]rune, 0, end), st.rows[row]...)
+		for len(next.rows[row]) < end {
+			next.rows[row] = append(next.rows[row], ' ')
+		}
+		for i, ink := range t.rows[row] {
+			if ink != ' ' {
+				next.rows[row][start+i] = ink
+				next.edge[row] = max(next.edge[row], start+i)
+			}
+		}
+	}
+	return next
+}
+
+// key identifies everything about st that later glyphs depend on
+func (st overlapState) key() string {
+	var sb strings.Builder
+	fmt.Fprint(&sb, st.end, st.verified, st.edge)
+	for _, row := range st.rows {
+		sb.WriteByte('|')
+		sb.WriteString(string(row[st.verified:]))
+	}
+	return sb.String()
+}
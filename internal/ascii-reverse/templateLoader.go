@@ -1,20 +1,20 @@
 package asciireverse
 
 import (
+	"ascii-art/internal/ascii"
 	"strings"
+	"unicode/utf8"
 )
 
 // CharacterTemplate represents an ASCII art character pattern
 type CharacterTemplate struct {
 	Char    rune     // The character this template represents
-	Pattern []string // 8 lines representing the ASCII art pattern
+	Pattern []string // Glyph rows representing the ASCII art pattern
 }
 
-// LoadBannerTemplates loads the banner file and creates a map of character patterns
-// Returns a map where key is the character and value is its 8-line ASCII pattern
-func LoadBannerTemplates(bannerContent string) (map[rune][]string, error) {
-	templates := make(map[rune][]string)
-
+// LoadBannerTemplates loads the banner file and creates a font of character patterns
+// Returns a font whose glyphs map each character to its ASCII pattern
+func LoadBannerTemplates(bannerContent string) (*ascii.Font, error) {
 	// Replace line endings with Unix line endings (LF)
 	// This handles banners that may have been created on Windows
 	bannerContent = strings.ReplaceAll(bannerContent, "\r\n", "\n")
@@ -25,40 +25,16 @@ func LoadBannerTemplates(bannerContent string) (map[rune][]string, error) {
 	// Split banner content into lines
 	lines := strings.Split(bannerContent, "\n")
 
-	// ASCII printable characters range from 32 (space) to 126 (~)
-	// Each character has 9 lines (1 header + 8 pattern lines)
-	for char := ' '; char <= '~'; char++ {
-		// Calculate starting line for this character
-		// First line (char 32, space) starts at line 1 (line 0 is usually empty/header)
-		startLine := int(char-32)*9 + 1
-
-		// Check if we have enough lines
-		if startLine+8 > len(lines) {
-			break
-		}
-
-		// Extract 8 lines for this character
-		pattern := make([]string, 8)
-		for i := 0; i < 8; i++ {
-			if startLine+i < len(lines) {
-				pattern[i] = lines[startLine+i]
-			} else {
-				pattern[i] = ""
-			}
-		}
-
-		templates[char] = pattern
-	}
-
-	return templates, nil
+	// Parse the lines with the same rules used for rendering, keeping the glyphs of a partial banner
+	return ascii.ParsePartialBanner("", lines)
 }
 
 // GetCharacterWidth calculates the width of a character pattern
-// Returns the length of the first non-empty line
+// Returns the width in runes of the first non-empty line
 func GetCharacterWidth(pattern []string) int {
 	for _, line := range pattern {
-		if len(line) > 0 {
-			return len(line)
+		if n := utf8.RuneCountInString(line); n > 0 {
+			return n
 		}
 	}
 	return 0
@@ -0,0 +1,111 @@
+package ascii
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultPreviewText is the sample rendered by --preview when no text is given
+const DefaultPreviewText = "Hello, World!"
+
+// BannerInfo describes one banner on the search path, as listed by --list-banners
+type BannerInfo struct {
+	Name string // Name the banner is chosen by
+	Path string // File the banner is read from
+	Font *Font  // Parsed banner, nil when it could not be loaded
+	Err  error  // Why the banner could not be loaded
+}
+
+// List loads every banner on the search path in alphabetical order
+// A banner that fails to load is still listed, with the error that stopped it.
+func (r *Registry) List() []BannerInfo {
+	var infos []BannerInfo
+	for _, name := range r.Names() {
+		source, file, ok := r.Find(name)
+		if !ok {
+			continue
+		}
+		font, err := source.Load(name, file)
+		infos = append(infos, BannerInfo{Name: name, Path: source.Path(file), Font: font, Err: err})
+	}
+	return infos
+}
+
+// Metadata returns the header values of the banner as "key: value" lines
+// Layouts are always given; the name, author and description only when the banner sets them.
+func (b BannerInfo) Metadata() []string {
+	var lines []string
+	if b.Font.Name != b.Name {
+		lines = append(lines, "name: "+b.Font.Name)
+	}
+	if b.Font.Author != "" {
+		lines = append(lines, "author: "+b.Font.Author)
+	}
+	if b.Font.Description != "" {
+		lines = append(lines, "description: "+strings.ReplaceAll(b.Font.Description, "\n", " "))
+	}
+
+	layout, _ := b.Font.HorizontalLayout()
+	vlayout, _ := b.Font.VerticalLayout()
+	return append(lines, "layout: "+layoutName(layout), "vlayout: "+layoutName(vlayout))
+}
+
+// layoutName returns the name --layout accepts for mode
+func layoutName(mode Layout) string {
+	for name, m := range LayoutNames {
+		if m == mode {
+			return name
+		}
+	}
+	return fmt.Sprint(mode)
+}
+
+// WriteBannerList writes the name, height, glyph count, source and metadata of each banner to w
+func WriteBannerList(w io.Writer, infos []BannerInfo) error {
+	for i, info := range infos {
+		lines := []string{info.Name}
+		if i > 0 {
+			lines = append([]string{""}, lines...)
+		}
+
+		if info.Err != nil {
+			lines = append(lines, "  source: "+info.Path, "  error: "+info.Err.Error())
+		} else {
+			lines = append(lines, fmt.Sprintf("  height: %d", info.Font.Height), fmt.Sprintf("  glyphs: %d", len(info.Font.Glyphs)), "  source: "+info.Path)
+			for _, line := range info.Metadata() {
+				lines = append(lines, "  "+line)
+			}
+		}
+
+		if err := WriteLines(w, lines); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WritePreview renders text in each banner, one after another, under the banner's name
+// A banner that cannot be loaded or cannot draw text shows why instead of its art.
+func WritePreview(w io.Writer, infos []BannerInfo, text string, opts Options) error {
+	for i, info := range infos {
+		lines := []string{info.Name + ":"}
+		if i > 0 {
+			lines = append([]string{""}, lines...)
+		}
+
+		if info.Err == nil {
+			info.Err = CheckGlyphs(text, info.Font, opts)
+		}
+		if info.Err != nil {
+			lines = append(lines, info.Err.Error())
+		} else {
+			lines = append(lines, Render(text, info.Font, opts).Lines()...)
+		}
+
+		if err := WriteLines(w, lines); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package ascii
+
+// SrcBorder is the source of the cells of a border, so they can be colored apart from the text
+const SrcBorder = -2
+
+// BorderNames maps the styles accepted by --border to their characters
+// Characters are listed in reading order: top left, top, top right, left, right, bottom left, bottom, bottom right.
+var BorderNames = map[string][8]rune{
+	"ascii":   {'+', '-', '+', '|', '|', '+', '-', '+'},
+	"single":  {'┌', '─', '┐', '│', '│', '└', '─', '┘'},
+	"double":  {'╔', '═', '╗', '║', '║', '╚', '═', '╝'},
+	"rounded": {'╭', '─', '╮', '│', '│', '╰', '─', '╯'},
+	"heavy":   {'┏', '━', '┓', '┃', '┃', '┗', '━', '┛'},
+}
+
+// Box is a number of columns or rows on each side of a block
+type Box struct {
+	Top, Right, Bottom, Left int
+}
+
+// Border frames the finished block, with padding inside the frame and a margin outside it
+// The zero value draws nothing around the art.
+type Border struct {
+	Chars   [8]rune // Frame characters in the order of BorderNames, all zero for no frame
+	Padding Box     // Blank space between the art and the frame
+	Margin  Box     // Blank space around the frame
+}
+
+// IsZero reports whether b leaves the art as it is
+func (b Border) IsZero() bool {
+	return b == Border{}
+}
+
+// framed reports whether b draws a frame
+func (b Border) framed() bool {
+	return b.Chars != [8]rune{}
+}
+
+// Size returns the columns and rows b adds around the art
+func (b Border) Size() (int, int) {
+	columns := b.Padding.Left + b.Padding.Right + b.Margin.Left + b.Margin.Right
+	rows := b.Padding.Top + b.Padding.Bottom + b.Margin.Top + b.Margin.Bottom
+	if b.framed() {
+		columns += 2
+		rows += 2
+	}
+	return columns, rows
+}
+
+// Inner returns the width left for the art when the bordered block must fit in width columns
+// A width of 0 or less means no limit and is returned unchanged.
+func (b Border) Inner(width int) int {
+	if width <= 0 {
+		return width
+	}
+	columns, _ := b.Size()
+	return max(width-columns, 1)
+}
+
+// Apply returns the canvas inside b
+// Every row is padded to the widest, so the bordered block is a rectangle that aligns as a unit.
+// Padding and margin cells have no source, frame cells have SrcBorder.
+func (b Border) Apply(c *Canvas) *Canvas {
+	if b.IsZero() {
+		return c
+	}
+
+	// The art and its padding
+	inner := c.Width() + b.Padding.Left + b.Padding.Right
+	body := blankCells(b.Padding.Top, inner)
+	for _, content := range c.Rows {
+		row := append(blankRow(b.Padding.Left), content...)
+		body = append(body, append(row, blankRow(inner-len(row))...))
+	}
+	body = append(body, blankCells(b.Padding.Bottom, inner)...)
+
+	if b.framed() {
+		for i, row := range body {
+			body[i] = append(append([]Cell{b.cell(3)}, row...), b.cell(4))
+		}
+		body = append([][]Cell{b.edge(0, 1, 2, inner)}, body...)
+		body = append(body, b.edge(5, 6, 7, inner))
+	}
+
+	columns, _ := b.Size()
+	width := c.Width() + columns
+	rows := blankCells(b.Margin.Top, width)
+	for _, row := range body {
+		rows = append(rows, append(append(blankRow(b.Margin.Left), row...), blankRow(b.Margin.Right)...))
+	}
+	rows = append(rows, blankCells(b.Margin.Bottom, width)...)
+	return &Canvas{Rows: rows}
+}
+
+// edge returns a top or bottom row of the frame: a corner, width edge characters, then a corner
+func (b Border) edge(left, fill, right, width int) []Cell {
+	row := []Cell{b.cell(left)}
+	for range width {
+		row = append(row, b.cell(fill))
+	}
+	return append(row, b.cell(right))
+}
+
+// cell returns a frame cell drawn with the character at index i of b.Chars
+func (b Border) cell(i int) Cell {
+	return Cell{Ch: b.Chars[i], Src: SrcBorder}
+}
+
+// blankRow returns n blank cells with no source
+func blankRow(n int) []Cell {
+	row := make([]Cell, n)
+	for i := range row {
+		row[i] = Cell{Ch: ' ', Src: -1}
+	}
+	return row
+}
+
+// BorderLines returns lines inside b, like Border.Apply
+func BorderLines(lines []string, b Border) []string {
+	if b.IsZero() {
+		return lines
+	}
+	return b.Apply(linesCanvas(lines)).Lines()
+}
@@ -0,0 +1,61 @@
+package ascii
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Cell is a single character position in rendered ASCII art
+// Src is what lets colors follow the input characters: every stage after layout, from fills and scaling
+// to transforms, effects, inversion, shadows, borders, stacking and justify, moves or restyles cells
+// but keeps their Src. Cells a stage adds have no source, or SrcBorder or SrcShadow.
+type Cell struct {
+	Ch  rune // Character drawn in this cell
+	Src int  // Byte offset in the input of the character that drew this cell, -1 if none, SrcBorder for a frame
+}
+
+// Canvas is rendered ASCII art kept as a grid of cells, one slice per output row
+// Keeping the source of every cell lets later stages (color, alignment) work on the real layout
+type Canvas struct {
+	Rows [][]Cell
+}
+
+// Lines returns the canvas as plain strings, one per row
+func (c *Canvas) Lines() []string {
+	lines := make([]string, len(c.Rows))
+	for i, row := range c.Rows {
+		lines[i] = rowString(row)
+	}
+	return lines
+}
+
+// Width returns the number of cells in the widest row
+func (c *Canvas) Width() int {
+	width := 0
+	for _, row := range c.Rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	return width
+}
+
+// Height returns the number of rows in the canvas
+func (c *Canvas) Height() int {
+	return len(c.Rows)
+}
+
+// rowString joins the characters of a row of cells
+// ASCII cells, nearly all of them in practice, are written as single bytes
+func rowString(row []Cell) string {
+	var sb strings.Builder
+	sb.Grow(len(row))
+	for _, cell := range row {
+		if cell.Ch < utf8.RuneSelf {
+			sb.WriteByte(byte(cell.Ch))
+		} else {
+			sb.WriteRune(cell.Ch)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+package ascii
+
+// Direction is the way characters follow one another in the rendered art
+type Direction int
+
+const (
+	DirectionHorizontal Direction = iota // Characters side by side, lines of text below each other
+	DirectionVertical                    // Characters below each other, lines of text side by side
+)
+
+// DirectionNames maps the names accepted by --direction to directions
+var DirectionNames = map[string]Direction{
+	"horizontal": DirectionHorizontal,
+	"vertical":   DirectionVertical,
+}
+
+// renderColumn lays out a line of text that starts at byte offset top to bottom, one glyph below another
+// Glyphs are centred in a column as wide as the widest of them and stacked with the vertical layout.
+// An empty line is a blank column as wide as a space.
+func renderColumn(line string, offset int, font *Font, fallback Fallback, vs vsmusher) [][]Cell {
+	glyphs := make([]placedGlyph, 0, len(line))
+	width := 0
+	for i, ch := range line {
+		glyph, ok := fallback.resolve(font, ch)
+		if !ok {
+			continue // skipped by the fallback policy
+		}
+
+		src := offset + i
+		if !glyph.ink {
+			src = -1 // placeholder is not part of the character art
+		}
+		glyphs = append(glyphs, placedGlyph{tableGlyph: glyph.tableGlyph, src: src})
+		width = max(width, glyph.width)
+	}
+
+	if len(glyphs) == 0 {
+		return blankCells(font.Height, font.Width(' '))
+	}
+
+	var rows [][]Cell
+	prevHeight := 0
+	for _, glyph := range glyphs {
+		left := (width - glyph.width) / 2
+
+		block := blankCells(font.Height, width)
+		for row := range block {
+			placed := glyph.appendRow(nil, row)
+			for col, cell := range placed {
+				// Hardblanks only matter while smushing side by side, they are drawn as spaces
+				if font.Hardblank != 0 && cell.Ch == font.Hardblank {
+					cell.Ch = ' '
+				}
+				block[row][left+col] = cell
+			}
+		}
+
+		rows = vs.stack(rows, block, prevHeight, len(rows) > 0)
+		prevHeight = len(block)
+	}
+	return rows
+}
+
+// blankCells returns height rows of width spaces that come from no input
+func blankCells(height, width int) [][]Cell {
+	rows := make([][]Cell, height)
+	cells := make([]Cell, height*width)
+	for i := range cells {
+		cells[i] = Cell{Ch: ' ', Src: -1}
+	}
+	for row := range rows {
+		rows[row] = cells[row*width : (row+1)*width : (row+1)*width]
+	}
+	return rows
+}
+
+// besideColumn places column to the right of rows, gap blank columns away, padding the shorter of the two with blank rows
+func besideColumn(rows [][]Cell, column [][]Cell, gap int) [][]Cell {
+	width := gridWidth(rows)
+	if len(rows) > 0 {
+		width += gap
+	}
+	columnWidth := gridWidth(column)
+
+	for len(rows) < len(column) {
+		rows = append(rows, blankCells(1, width)[0])
+	}
+	for row := range rows {
+		for len(rows[row]) < width {
+			rows[row] = append(rows[row], Cell{Ch: ' ', Src: -1})
+		}
+		if row < len(column) {
+			rows[row] = append(rows[row], column[row]...)
+		}
+		for len(rows[row]) < width+columnWidth {
+			rows[row] = append(rows[row], Cell{Ch: ' ', Src: -1})
+		}
+	}
+	return rows
+}
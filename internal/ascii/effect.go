@@ -0,0 +1,137 @@
+package ascii
+
+// EffectMode selects how the ink of the finished block is redrawn
+type EffectMode int
+
+const (
+	EffectNone    EffectMode = iota // Leave the ink as drawn
+	EffectOutline                   // Draw only the edge of the ink, with one character or box drawing
+	EffectHollow                    // Keep the edge of the ink as drawn and blank the inside
+)
+
+// EffectNames maps the names accepted by --effect to effect modes
+var EffectNames = map[string]EffectMode{
+	"outline": EffectOutline,
+	"hollow":  EffectHollow,
+}
+
+// Directions an outline cell connects to, combined into an index of an outline set
+const (
+	linkUp    = 1
+	linkRight = 2
+	linkDown  = 4
+	linkLeft  = 8
+)
+
+// OutlineSets maps the box-drawing sets accepted by --effect=outline to their characters, indexed by connections
+var OutlineSets = map[string][16]rune{
+	"ascii":   outlineSet('-', '|', '+', '+', '+', '+', '+', '+', '+', '+', '+'),
+	"single":  outlineSet('─', '│', '┌', '┐', '└', '┘', '├', '┤', '┬', '┴', '┼'),
+	"double":  outlineSet('═', '║', '╔', '╗', '╚', '╝', '╠', '╣', '╦', '╩', '╬'),
+	"rounded": outlineSet('─', '│', '╭', '╮', '╰', '╯', '├', '┤', '┬', '┴', '┼'),
+	"heavy":   outlineSet('━', '┃', '┏', '┓', '┗', '┛', '┣', '┫', '┳', '┻', '╋'),
+}
+
+// outlineSet indexes box-drawing characters by the directions they connect
+// A cell connected one way continues that line, and a cell on its own is drawn as a cross.
+func outlineSet(horizontal, vertical, downRight, downLeft, upRight, upLeft, teeRight, teeLeft, teeDown, teeUp, cross rune) [16]rune {
+	var set [16]rune
+	set[0] = cross
+	set[linkLeft], set[linkRight], set[linkLeft|linkRight] = horizontal, horizontal, horizontal
+	set[linkUp], set[linkDown], set[linkUp|linkDown] = vertical, vertical, vertical
+	set[linkDown|linkRight] = downRight
+	set[linkDown|linkLeft] = downLeft
+	set[linkUp|linkRight] = upRight
+	set[linkUp|linkLeft] = upLeft
+	set[linkUp|linkDown|linkRight] = teeRight
+	set[linkUp|linkDown|linkLeft] = teeLeft
+	set[linkLeft|linkRight|linkDown] = teeDown
+	set[linkLeft|linkRight|linkUp] = teeUp
+	set[linkUp|linkRight|linkDown|linkLeft] = cross
+	return set
+}
+
+// Effect redraws the ink of the finished block from the neighbourhood of each cell
+// Ink is every cell that isn't blank; it is on the edge when one of its 8 neighbours is blank or off the block.
+// The zero value leaves the ink as drawn.
+type Effect struct {
+	Mode EffectMode // What happens to the ink
+	Char rune       // Character an outline is drawn with, 0 to use Box
+	Box  [16]rune   // Box-drawing set an outline is drawn with, from OutlineSets
+}
+
+// Apply returns the canvas with e applied to its ink
+func (e Effect) Apply(c *Canvas) *Canvas {
+	if e.Mode == EffectNone {
+		return c
+	}
+
+	ink := func(r, col int) bool {
+		return r >= 0 && r < len(c.Rows) && col >= 0 && col < len(c.Rows[r]) && c.Rows[r][col].Ch != ' '
+	}
+
+	// Find the edge of the ink once, outlines look at the edge around each cell
+	edges := make([][]bool, len(c.Rows))
+	for r, row := range c.Rows {
+		edges[r] = make([]bool, len(row))
+		for col := range row {
+			if !ink(r, col) {
+				continue
+			}
+			for dr := -1; dr <= 1 && !edges[r][col]; dr++ {
+				for dc := -1; dc <= 1; dc++ {
+					if !ink(r+dr, col+dc) {
+						edges[r][col] = true
+						break
+					}
+				}
+			}
+		}
+	}
+	edge := func(r, col int) bool {
+		return r >= 0 && r < len(edges) && col >= 0 && col < len(edges[r]) && edges[r][col]
+	}
+
+	rows := make([][]Cell, len(c.Rows))
+	for r, row := range c.Rows {
+		rows[r] = append([]Cell{}, row...)
+		for col := range row {
+			switch {
+			case !ink(r, col):
+			case !edge(r, col):
+				rows[r][col].Ch = ' '
+			case e.Mode == EffectOutline && e.Char != 0:
+				rows[r][col].Ch = e.Char
+			case e.Mode == EffectOutline:
+				rows[r][col].Ch = e.Box[links(edge, r, col)]
+			}
+		}
+	}
+	return &Canvas{Rows: rows}
+}
+
+// links returns the directions from the cell at r, col to the edge cells beside it
+func links(edge func(r, col int) bool, r, col int) int {
+	links := 0
+	if edge(r-1, col) {
+		links |= linkUp
+	}
+	if edge(r, col+1) {
+		links |= linkRight
+	}
+	if edge(r+1, col) {
+		links |= linkDown
+	}
+	if edge(r, col-1) {
+		links |= linkLeft
+	}
+	return links
+}
+
+// EffectLines returns lines with e applied, like Effect.Apply
+func EffectLines(lines []string, e Effect) []string {
+	if e.Mode == EffectNone {
+		return lines
+	}
+	return e.Apply(linesCanvas(lines)).Lines()
+}
@@ -0,0 +1,153 @@
+package ascii
+
+import (
+	"strings"
+	"sync"
+)
+
+// FallbackMode selects what is drawn for a character that no font in the chain defines
+type FallbackMode int
+
+const (
+	FallbackBlank   FallbackMode = iota // Draw an 8 column blank placeholder
+	FallbackSkip                        // Leave the character out
+	FallbackReplace                     // Draw a boxed replacement character
+	FallbackError                       // Refuse to render, see CheckGlyphs
+)
+
+// FallbackModeNames maps the policy keywords accepted by --fallback to modes
+var FallbackModeNames = map[string]FallbackMode{
+	"blank":   FallbackBlank,
+	"skip":    FallbackSkip,
+	"replace": FallbackReplace,
+	"error":   FallbackError,
+}
+
+// DefaultReplacement is the character drawn inside the replacement box
+const DefaultReplacement = '?'
+
+// blankWidth is the width of the placeholder drawn for missing characters
+const blankWidth = 8
+
+// Fallback is the missing-glyph policy shared by rendering, justify and measuring
+// The zero value draws a blank placeholder without trying other fonts
+type Fallback struct {
+	Fonts       []*Font      // Fonts tried in order when the banner lacks a character
+	Mode        FallbackMode // Applied when no font in the chain has the character
+	Replacement rune         // Character drawn in the box for FallbackReplace, DefaultReplacement when zero
+}
+
+// resolvedGlyph is what gets drawn for one character of input
+type resolvedGlyph struct {
+	*tableGlyph      // Glyph rows and width; blankGlyph for the placeholder
+	ink         bool // Whether the cells belong to the input character, false for the placeholder
+}
+
+// resolve finds the glyph drawn for ch with font and the fallback policy
+// Returns false when the character is skipped
+func (f Fallback) resolve(font *Font, ch rune) (resolvedGlyph, bool) {
+	if g, ok := font.tableGlyph(ch); ok {
+		return resolvedGlyph{tableGlyph: g, ink: true}, true
+	}
+
+	// Glyphs from other fonts are cut or padded to the banner height by renderLine
+	// Hardblanks of another font mean nothing to the banner, so those fonts give their display rows
+	for _, other := range f.Fonts {
+		if g, ok := other.displayGlyph(ch); ok {
+			return resolvedGlyph{tableGlyph: g, ink: true}, true
+		}
+	}
+
+	switch f.Mode {
+	case FallbackSkip:
+		return resolvedGlyph{}, false
+	case FallbackReplace:
+		return resolvedGlyph{tableGlyph: preparedReplacement(font.Height, f.replacement()), ink: true}, true
+	default:
+		return resolvedGlyph{tableGlyph: blankGlyph}, true
+	}
+}
+
+// Has reports whether font or one of the fallback fonts defines ch
+func (f Fallback) Has(font *Font, ch rune) bool {
+	if font.Has(ch) {
+		return true
+	}
+	for _, other := range f.Fonts {
+		if other.Has(ch) {
+			return true
+		}
+	}
+	return false
+}
+
+// replacement returns the character drawn in the replacement box
+func (f Fallback) replacement() rune {
+	if f.Replacement == 0 {
+		return DefaultReplacement
+	}
+	return f.Replacement
+}
+
+// replacementKey identifies a prepared replacement glyph
+type replacementKey struct {
+	height int
+	ch     rune
+}
+
+// replacements caches prepared replacement glyphs, shared by every fallback policy and goroutine
+var replacements sync.Map // replacementKey -> *tableGlyph
+
+// preparedReplacement returns the replacement glyph for ch as tall as height, prepared once for each size
+func preparedReplacement(height int, ch rune) *tableGlyph {
+	key := replacementKey{height: height, ch: ch}
+	if g, ok := replacements.Load(key); ok {
+		return g.(*tableGlyph)
+	}
+	g, _ := replacements.LoadOrStore(key, newTableGlyph(replacementGlyph(height, ch)))
+	return g.(*tableGlyph)
+}
+
+// replacementGlyph draws ch in a box as tall as height
+// Fonts shorter than three rows get a bracketed character instead
+func replacementGlyph(height int, ch rune) []string {
+	rows := make([]string, height)
+	middle := (height - 1) / 2
+
+	if height < 3 {
+		for i := range rows {
+			rows[i] = "   "
+		}
+		rows[middle] = "[" + string(ch) + "]"
+		return rows
+	}
+
+	for i := range rows {
+		switch {
+		case i == 0 || i == height-1:
+			rows[i] = "+---+"
+		case i == middle:
+			rows[i] = "| " + string(ch) + " |"
+		default:
+			rows[i] = "|   |"
+		}
+	}
+	return rows
+}
+
+// CheckGlyphs reports the first character of input that cannot be drawn under the FallbackError policy
+// The other policies always succeed, so the check only fails when opts asks for errors
+func CheckGlyphs(input string, font *Font, opts Options) error {
+	if opts.Fallback.Mode != FallbackError {
+		return nil
+	}
+
+	for _, line := range strings.Split(input, "\n") {
+		for _, ch := range line {
+			if !opts.Fallback.Has(font, ch) {
+				return WrapMissingGlyphError(ch, font.Name)
+			}
+		}
+	}
+	return nil
+}
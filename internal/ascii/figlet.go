@@ -0,0 +1,193 @@
+package ascii
+
+import (
+	"ascii-art/internal/files"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FigletSignature is the magic prefix of every FIGlet font header
+const FigletSignature = "flf2a"
+
+// FigletExt is the file extension used by FIGlet fonts
+const FigletExt = ".flf"
+
+// ToiletSignature is the magic prefix of a TOIlet font header
+// TOIlet fonts use the FIGlet layout but are UTF-8 and may draw with any Unicode character
+const ToiletSignature = "tlf2a"
+
+// ToiletExt is the file extension used by TOIlet fonts
+const ToiletExt = ".tlf"
+
+// deutschRunes are the German characters every FIGlet font defines after the printable ASCII set
+var deutschRunes = []rune{196, 214, 220, 228, 246, 252, 223}
+
+// LoadFigletFile loads a FIGlet .flf or TOIlet .tlf font from path
+func LoadFigletFile(path string) (*Font, error) {
+	lines, err := files.ReadBannerLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return ParseFiglet(name, lines)
+}
+
+// ParseFiglet builds a Font from the lines of a FIGlet or TOIlet font file
+// Header: flf2a<hardblank> (tlf2a<hardblank> for TOIlet) height baseline max_length old_layout comment_lines [print_direction [full_layout [codetag_count]]]
+func ParseFiglet(name string, lines []string) (*Font, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("figlet font %s: file is empty", name)
+	}
+
+	font, commentLines, err := parseFigletHeader(name, strings.TrimRight(lines[0], "\r"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Comment lines follow the header and describe the font
+	i := 1
+	if i+commentLines > len(lines) {
+		return nil, fmt.Errorf("figlet font %s: missing comment lines", name)
+	}
+	comments := make([]string, 0, commentLines)
+	for _, line := range lines[i : i+commentLines] {
+		comments = append(comments, strings.TrimRight(line, "\r"))
+	}
+	font.Description = strings.TrimSpace(strings.Join(comments, "\n"))
+	i += commentLines
+
+	// Required characters: printable ASCII followed by the Deutsch set
+	required := make([]rune, 0, 95+len(deutschRunes))
+	for ch := rune(32); ch <= 126; ch++ {
+		required = append(required, ch)
+	}
+	required = append(required, deutschRunes...)
+
+	for _, ch := range required {
+		if i+font.Height > len(lines) {
+			// Some fonts stop early; everything read so far is still usable
+			return font, nil
+		}
+		font.setRawGlyph(ch, readFigletGlyph(lines[i:i+font.Height]))
+		i += font.Height
+	}
+
+	// Code-tagged characters: a "<code> [description]" line followed by the glyph rows
+	for i < len(lines) {
+		tag := strings.TrimSpace(strings.TrimRight(lines[i], "\r"))
+		i++
+		if tag == "" {
+			continue
+		}
+		if i+font.Height > len(lines) {
+			break
+		}
+
+		fields := strings.Fields(tag)
+		code, err := strconv.ParseInt(fields[0], 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("figlet font %s: invalid character code %q", name, fields[0])
+		}
+
+		rows := readFigletGlyph(lines[i : i+font.Height])
+		i += font.Height
+
+		// Negative codes are translation-table entries with no Unicode equivalent
+		if code >= 0 {
+			font.setRawGlyph(rune(code), rows)
+		}
+	}
+
+	return font, nil
+}
+
+// parseFigletHeader reads the first line of a FIGlet font
+// Returns the partially filled font and the number of comment lines that follow
+func parseFigletHeader(name, header string) (*Font, int, error) {
+	signature := FigletSignature
+	if strings.HasPrefix(header, ToiletSignature) {
+		signature = ToiletSignature
+	}
+	if !strings.HasPrefix(header, signature) || len(header) <= len(signature) {
+		return nil, 0, fmt.Errorf("figlet font %s: missing %s or %s signature", name, FigletSignature, ToiletSignature)
+	}
+
+	fields := strings.Fields(header)
+	if len(fields) < 6 {
+		return nil, 0, fmt.Errorf("figlet font %s: header needs at least 6 fields, got %d", name, len(fields))
+	}
+
+	// The character right after the signature is the hardblank
+	hardblank := []rune(fields[0][len(signature):])[0]
+
+	values := make([]int, len(fields)-1)
+	for j, field := range fields[1:] {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, 0, fmt.Errorf("figlet font %s: invalid header value %q", name, field)
+		}
+		values[j] = value
+	}
+
+	font := &Font{
+		Name:       name,
+		Hardblank:  hardblank,
+		Height:     values[0],
+		Baseline:   values[1],
+		MaxLength:  values[2],
+		OldLayout:  values[3],
+		FullLayout: fullLayoutFromOld(values[3]),
+	}
+	commentLines := values[4]
+
+	if len(values) > 5 {
+		font.PrintDirection = values[5]
+	}
+	if len(values) > 6 {
+		font.FullLayout = values[6]
+	}
+
+	if font.Height <= 0 {
+		return nil, 0, fmt.Errorf("figlet font %s: invalid height %d", name, font.Height)
+	}
+	if commentLines < 0 {
+		return nil, 0, fmt.Errorf("figlet font %s: invalid comment line count %d", name, commentLines)
+	}
+
+	return font, commentLines, nil
+}
+
+// readFigletGlyph strips the endmarks from each glyph row
+func readFigletGlyph(lines []string) []string {
+	rows := make([]string, len(lines))
+	for i, line := range lines {
+		rows[i] = stripEndmark(line)
+	}
+	return rows
+}
+
+// stripEndmark removes trailing whitespace and then the run of endmark characters closing a row
+func stripEndmark(line string) string {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" {
+		return line
+	}
+
+	runes := []rune(line)
+	endmark := runes[len(runes)-1]
+	k := len(runes)
+	for k > 0 && runes[k-1] == endmark {
+		k--
+	}
+
+	return string(runes[:k])
+}
+
+// IsFigletFile reports whether path names a FIGlet or TOIlet font file
+func IsFigletFile(path string) bool {
+	ext := filepath.Ext(path)
+	return strings.EqualFold(ext, FigletExt) || strings.EqualFold(ext, ToiletExt)
+}
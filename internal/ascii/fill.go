@@ -0,0 +1,45 @@
+package ascii
+
+import "unicode/utf8"
+
+// Fill restyles the ink of the art, the cells glyphs draw that aren't blank
+// The zero value keeps the characters of the banner.
+type Fill struct {
+	Texture []rune // Characters drawn in turn over the ink of each line of text, one for a solid fill
+	Source  bool   // Draw the ink of each glyph with the input character it stands for
+}
+
+// IsZero reports whether f keeps the characters of the banner
+func (f Fill) IsZero() bool {
+	return len(f.Texture) == 0 && !f.Source
+}
+
+// apply restyles the ink of the blocks rendered from line, which starts at offset in the input
+// Ink is found once the line is laid out, so smushing still sees the banner's own characters.
+// Placeholders and hyphens stand for no input character, so Source leaves them as drawn.
+func (f Fill) apply(blocks [][][]Cell, line string, offset int) {
+	if f.IsZero() {
+		return
+	}
+
+	next := 0
+	for _, block := range blocks {
+		for _, row := range block {
+			for i, cell := range row {
+				if cell.Ch == ' ' {
+					continue
+				}
+
+				switch {
+				case f.Source:
+					if cell.Src >= offset && cell.Src < offset+len(line) {
+						row[i].Ch, _ = utf8.DecodeRuneInString(line[cell.Src-offset:])
+					}
+				default:
+					row[i].Ch = f.Texture[next%len(f.Texture)]
+					next++
+				}
+			}
+		}
+	}
+}
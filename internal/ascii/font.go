@@ -0,0 +1,164 @@
+package ascii
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultHeight is the glyph height used by the classic banner files
+const DefaultHeight = 8
+
+// Font holds every glyph of a banner together with its dimensions and metadata
+type Font struct {
+	Name        string            // Banner name, e.g. "standard"
+	Author      string            // Optional author from the banner header
+	Description string            // Optional description from the banner header
+	Height      int               // Number of rows in every glyph
+	Glyphs      map[rune][]string // Glyph rows keyed by character, change them with SetGlyph
+	Widths      map[rune]int      // Width of each glyph in columns
+
+	// FIGlet header values, zero for classic banner files
+	Hardblank      rune // Character drawn as a space that is never smushed
+	Baseline       int  // Rows from the top of a glyph to its baseline
+	MaxLength      int  // Longest row in the font file, including endmarks
+	OldLayout      int  // Horizontal layout from the old header field
+	FullLayout     int  // Layout mode and smushing rule bits, see HorizontalLayout
+	PrintDirection int  // 0 for left-to-right, 1 for right-to-left: the first character is drawn rightmost
+
+	// raw holds glyph rows that still contain hardblanks, used for smushing
+	raw map[rune][]string
+
+	// table holds every glyph prepared for rendering, kept in step by SetGlyph
+	table glyphTable
+
+	// display holds the glyphs in raw prepared with hardblanks drawn as spaces, for use as a fallback font
+	display glyphTable
+}
+
+// NewFont builds a Font from a glyph map, deriving the height and widths from the glyphs
+func NewFont(name string, glyphs map[rune][]string) *Font {
+	font := &Font{
+		Name:   name,
+		Glyphs: make(map[rune][]string, len(glyphs)),
+		Widths: make(map[rune]int, len(glyphs)),
+	}
+
+	for ch, rows := range glyphs {
+		if len(rows) > font.Height {
+			font.Height = len(rows)
+		}
+		font.SetGlyph(ch, rows)
+	}
+
+	if font.Height == 0 {
+		font.Height = DefaultHeight
+	}
+
+	return font
+}
+
+// SetGlyph stores the rows for ch and records its width
+func (f *Font) SetGlyph(ch rune, rows []string) {
+	if f.Glyphs == nil {
+		f.Glyphs = make(map[rune][]string)
+	}
+	if f.Widths == nil {
+		f.Widths = make(map[rune]int)
+	}
+
+	f.Glyphs[ch] = rows
+	f.Widths[ch] = glyphWidth(rows)
+	delete(f.raw, ch)
+	f.table.set(ch, newTableGlyph(rows))
+}
+
+// setRawGlyph stores rows that may contain hardblanks, keeping a display copy in Glyphs
+func (f *Font) setRawGlyph(ch rune, rows []string) {
+	if f.Hardblank == 0 {
+		f.SetGlyph(ch, rows)
+		return
+	}
+
+	display := make([]string, len(rows))
+	for i, row := range rows {
+		display[i] = strings.ReplaceAll(row, string(f.Hardblank), " ")
+	}
+	f.SetGlyph(ch, display)
+	f.display.set(ch, f.table.get(ch))
+
+	if f.raw == nil {
+		f.raw = make(map[rune][]string)
+	}
+	f.raw[ch] = rows
+	f.table.set(ch, newTableGlyph(rows))
+}
+
+// displayGlyph returns the prepared glyph for ch with hardblanks drawn as spaces, and whether the font defines it
+func (f *Font) displayGlyph(ch rune) (*tableGlyph, bool) {
+	if _, ok := f.raw[ch]; ok {
+		return f.display.get(ch), true
+	}
+	return f.tableGlyph(ch)
+}
+
+// rawGlyph returns the rows for ch with hardblanks left in place
+func (f *Font) rawGlyph(ch rune) []string {
+	if rows, ok := f.raw[ch]; ok {
+		return rows
+	}
+	return f.Glyphs[ch]
+}
+
+// Glyph returns the rows for ch and whether the font defines it
+func (f *Font) Glyph(ch rune) ([]string, bool) {
+	rows, ok := f.Glyphs[ch]
+	return rows, ok
+}
+
+// Has reports whether the font defines a glyph for ch
+func (f *Font) Has(ch rune) bool {
+	_, ok := f.Glyphs[ch]
+	return ok
+}
+
+// Width returns the width of the glyph for ch, or 0 if the font does not define it
+func (f *Font) Width(ch rune) int {
+	return f.Widths[ch]
+}
+
+// Row returns a single row of the glyph for ch, padded with spaces when the glyph is shorter than the font
+func (f *Font) Row(ch rune, row int) string {
+	rows := f.Glyphs[ch]
+	if row < len(rows) {
+		return rows[row]
+	}
+	return strings.Repeat(" ", f.Widths[ch])
+}
+
+// Runes returns every character defined by the font in ascending order
+func (f *Font) Runes() []rune {
+	runes := make([]rune, 0, len(f.Glyphs))
+	for ch := range f.Glyphs {
+		runes = append(runes, ch)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes
+}
+
+// RightToLeft reports whether the font's print direction draws text from right to left
+func (f *Font) RightToLeft() bool {
+	return f.PrintDirection == 1
+}
+
+// glyphWidth returns the width of the widest row in a glyph
+// Widths count runes, so multi-byte ink such as block characters is one column wide
+func glyphWidth(rows []string) int {
+	width := 0
+	for _, row := range rows {
+		if n := utf8.RuneCountInString(row); n > width {
+			width = n
+		}
+	}
+	return width
+}
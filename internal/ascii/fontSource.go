@@ -0,0 +1,82 @@
+package ascii
+
+import (
+	"ascii-art/internal/files"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BannerExts lists the banner file extensions in lookup order
+var BannerExts = []string{".txt", FigletExt, ToiletExt}
+
+// FontSource is a directory of banner files, either on disk or embedded in the binary
+type FontSource struct {
+	Name string // Describes the source in listings, e.g. "banners" or "built-in"
+	FS   fs.FS  // Files of the source
+}
+
+// Find returns the file in the source that holds the banner called name
+func (s FontSource) Find(name string) (string, bool) {
+	if CheckBannerName(name) != nil {
+		return "", false
+	}
+
+	for _, ext := range BannerExts {
+		file := name + ext
+		if info, err := fs.Stat(s.FS, file); err == nil && !info.IsDir() {
+			return file, true
+		}
+	}
+
+	return "", false
+}
+
+// Load parses the banner called name from file, a file name returned by Find
+func (s FontSource) Load(name, file string) (*Font, error) {
+	lines, err := files.ReadBannerLinesFS(s.FS, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsFigletFile(file) {
+		return ParseFiglet(name, lines)
+	}
+	return ParseBanner(name, lines)
+}
+
+// Path returns where file, a file name returned by Find, is read from
+// Files on disk are joined to their directory; built-in files are marked as such.
+func (s FontSource) Path(file string) string {
+	if s.Name == BuiltinSource {
+		return file + " (" + BuiltinSource + ")"
+	}
+	return filepath.Join(s.Name, file)
+}
+
+// Names lists the banners in the source in alphabetical order
+func (s FontSource) Names() []string {
+	entries, err := fs.ReadDir(s.FS, ".")
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := path.Ext(entry.Name())
+		for _, known := range BannerExts {
+			if strings.EqualFold(ext, known) {
+				names = append(names, strings.TrimSuffix(entry.Name(), ext))
+				break
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
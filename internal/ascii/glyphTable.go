@@ -0,0 +1,104 @@
+package ascii
+
+import "strings"
+
+// glyphTableSize is the number of runes, from U+0000, kept in the dense part of a glyph table
+// It covers ASCII and Latin-1, which is nearly all text rendered with the bundled banners.
+const glyphTableSize = 256
+
+// tableGlyph is a glyph prepared for rendering: every row is split into runes and padded to width
+type tableGlyph struct {
+	rows  [][]rune // Rows with hardblanks left in place, all width runes long
+	text  []string // The same rows as strings, for writing art without cells
+	width int      // Width in columns
+}
+
+// newTableGlyph prepares rows for rendering
+func newTableGlyph(rows []string) *tableGlyph {
+	width := glyphWidth(rows)
+	g := &tableGlyph{rows: make([][]rune, len(rows)), text: make([]string, len(rows)), width: width}
+
+	// One backing array holds every row
+	runes := make([]rune, 0, len(rows)*width)
+	for i, row := range rows {
+		start := len(runes)
+		runes = append(runes, []rune(row)...)
+		for len(runes)-start < width {
+			runes = append(runes, ' ')
+		}
+		g.rows[i] = runes[start:len(runes):len(runes)]
+		g.text[i] = string(g.rows[i])
+	}
+	return g
+}
+
+// writeRow writes one row of the glyph to sb, each character sx times, hardblanks as spaces and spaces past the end of the glyph
+func (g *tableGlyph) writeRow(sb *strings.Builder, row int, hardblank rune, sx int) {
+	if row >= len(g.rows) {
+		for range g.width * sx {
+			sb.WriteByte(' ')
+		}
+		return
+	}
+	if sx == 1 && (hardblank == 0 || !strings.ContainsRune(g.text[row], hardblank)) {
+		sb.WriteString(g.text[row])
+		return
+	}
+	for _, r := range g.rows[row] {
+		if r == hardblank {
+			r = ' '
+		}
+		for range sx {
+			sb.WriteRune(r)
+		}
+	}
+}
+
+// blankGlyph is the placeholder drawn for missing characters by FallbackBlank
+var blankGlyph = &tableGlyph{width: blankWidth}
+
+// glyphTable indexes the prepared glyphs of a font by rune
+// Lookups for common characters are a slice index instead of a map lookup.
+type glyphTable struct {
+	dense  []*tableGlyph        // Glyphs for runes below glyphTableSize
+	sparse map[rune]*tableGlyph // Glyphs for every other rune
+}
+
+// set stores the prepared glyph for ch
+func (t *glyphTable) set(ch rune, g *tableGlyph) {
+	if ch >= 0 && ch < glyphTableSize {
+		if t.dense == nil {
+			t.dense = make([]*tableGlyph, glyphTableSize)
+		}
+		t.dense[ch] = g
+		return
+	}
+
+	if t.sparse == nil {
+		t.sparse = make(map[rune]*tableGlyph)
+	}
+	t.sparse[ch] = g
+}
+
+// get returns the prepared glyph for ch, or nil
+func (t *glyphTable) get(ch rune) *tableGlyph {
+	if ch >= 0 && ch < glyphTableSize {
+		if t.dense == nil {
+			return nil
+		}
+		return t.dense[ch]
+	}
+	return t.sparse[ch]
+}
+
+// tableGlyph returns the prepared glyph for ch and whether the font defines it
+// Fonts built without SetGlyph have no table entries, their glyphs are prepared on each call.
+func (f *Font) tableGlyph(ch rune) (*tableGlyph, bool) {
+	if g := f.table.get(ch); g != nil {
+		return g, true
+	}
+	if !f.Has(ch) {
+		return nil, false
+	}
+	return newTableGlyph(f.rawGlyph(ch)), true
+}
@@ -2,6 +2,7 @@ package ascii
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strings"
 )
@@ -16,17 +17,19 @@ var (
 	ErrEmptyInput = errors.New(`text input cannot be empty
 ` + Usage)
 
-	ErrInvalidBanner = errors.New(`invalid banner style
-valid banners: standard, shadow, thinkertoy
-note: if omitted, 'standard' will be used by default
+	ErrInvalidBanner = errors.New("invalid banner style")
 
-` + Usage)
+	ErrTextWithInput = errors.New(`text cannot be given when reading from --input or stdin
+EX: echo "text" | go run ./cmd standard`)
 )
 
-var ValidBanners = map[string]bool{
-	"standard":   true,
-	"shadow":     true,
-	"thinkertoy": true,
+// WrapInvalidBannerError wraps ErrInvalidBanner with the banners found on the search path
+func WrapInvalidBannerError(banner string) error {
+	return fmt.Errorf(`%w: %s
+valid banners: %s, or a FIGlet .flf or TOIlet .tlf font
+note: if omitted, 'standard' will be used by default
+
+%s`, ErrInvalidBanner, banner, strings.Join(Banners.Names(), ", "), Usage)
 }
 
 // GetUserInput validates and returns user input and banner
@@ -40,8 +43,10 @@ func GetUserInput() (string, string, error) {
 	}
 
 	// First argument is the text input
-	input := strings.TrimSpace(args[0])
-	input = strings.ReplaceAll(input, "\\n", "\n")
+	input, err := NormalizeText(strings.TrimSpace(args[0]), Normalization)
+	if err != nil {
+		return "", "", err
+	}
 
 	if input == "" {
 		return "", "", ErrEmptyInput
@@ -53,10 +58,26 @@ func GetUserInput() (string, string, error) {
 	// If second argument exists, it's the banner
 	if len(args) >= 2 {
 		banner = args[1]
-		if !ValidBanners[banner] {
-			return "", "", ErrInvalidBanner
+		if !IsValidBanner(banner) {
+			return "", "", WrapInvalidBannerError(banner)
 		}
 	}
 
 	return input, banner, nil
 }
+
+// GetStreamBanner validates the arguments left when the text comes from --input or stdin
+// Only a banner may be given; it defaults to standard
+func GetStreamBanner(args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		return "standard", nil
+	case 1:
+		if !IsValidBanner(args[0]) {
+			return "", WrapInvalidBannerError(args[0])
+		}
+		return args[0], nil
+	default:
+		return "", ErrTextWithInput
+	}
+}
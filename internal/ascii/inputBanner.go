@@ -0,0 +1,49 @@
+package ascii
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UsageBanner is the usage message for the banner flag
+const UsageBanner = `Usage: go run ./cmd [OPTION] [STRING]
+
+EX: go run ./cmd --banner=shadow "text"
+EX: echo "text" | go run ./cmd --banner=shadow`
+
+var (
+	// ErrInvalidBannerFormat is returned when the --banner flag format is incorrect
+	ErrInvalidBannerFormat = fmt.Errorf("invalid --banner flag format\n%s", UsageBanner)
+)
+
+// ParseBannerFlag extracts and validates the --banner flag
+// Returns: banner (empty when the flag is absent), remainingArgs, error
+func ParseBannerFlag(args []string) (string, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--banner" {
+			return "", nil, ErrInvalidBannerFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--banner=") {
+			banner := strings.TrimPrefix(arg, "--banner=")
+			if banner == "" {
+				return "", nil, ErrInvalidBannerFormat
+			}
+
+			if !IsValidBanner(banner) {
+				return "", nil, WrapInvalidBannerError(banner)
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return banner, remaining, nil
+		}
+	}
+
+	// No banner flag found
+	return "", args, nil
+}
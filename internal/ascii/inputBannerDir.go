@@ -0,0 +1,54 @@
+package ascii
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// UsageBannerDir is the usage message for the banner directory feature
+const UsageBannerDir = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --banner-dir=./fonts "text" big`
+
+var (
+	// ErrInvalidBannerDirFormat is returned when the --banner-dir flag format is incorrect
+	ErrInvalidBannerDirFormat = fmt.Errorf("invalid --banner-dir flag format\n%s", UsageBannerDir)
+)
+
+// WrapBannerDirError wraps an error for a banner directory that cannot be used
+func WrapBannerDirError(dir string) error {
+	return fmt.Errorf("banner directory not found: %s", dir)
+}
+
+// ParseBannerDirFlag extracts and validates the --banner-dir flag
+// Returns: directory (empty when the flag is absent), remainingArgs, error
+func ParseBannerDirFlag(args []string) (string, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--banner-dir" {
+			return "", nil, ErrInvalidBannerDirFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--banner-dir=") {
+			dir := strings.TrimPrefix(arg, "--banner-dir=")
+			if dir == "" {
+				return "", nil, ErrInvalidBannerDirFormat
+			}
+
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+				return "", nil, WrapBannerDirError(dir)
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return dir, remaining, nil
+		}
+	}
+
+	// No banner directory flag found
+	return "", args, nil
+}
@@ -0,0 +1,71 @@
+package ascii
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UsageBannerList is the usage message for the banner listing and preview features
+const UsageBannerList = `Usage: go run ./cmd --list-banners | --preview[=STRING] [OPTION]
+
+EX: go run ./cmd --list-banners
+EX: go run ./cmd --banner-dir=./fonts --preview
+EX: go run ./cmd --preview="Hi there" --scale=2`
+
+var (
+	// ErrInvalidListBannersFormat is returned when the --list-banners switch is given a value
+	ErrInvalidListBannersFormat = fmt.Errorf("invalid --list-banners flag format, --list-banners takes no value\n%s", UsageBannerList)
+
+	// ErrInvalidPreviewFormat is returned when the --preview flag format is incorrect
+	ErrInvalidPreviewFormat = fmt.Errorf("invalid --preview flag format\n%s", UsageBannerList)
+
+	// ErrBannerListArgs is returned when text or a banner is given along with --list-banners or --preview
+	ErrBannerListArgs = fmt.Errorf("--list-banners and --preview show every banner and take no text or banner arguments\n%s", UsageBannerList)
+)
+
+// ParseListBannersFlag extracts the --list-banners switch
+// Returns: whether the banners are listed, remainingArgs, error
+func ParseListBannersFlag(args []string) (bool, []string, error) {
+	for i, arg := range args {
+		// The switch takes no value
+		if strings.HasPrefix(arg, "--list-banners=") {
+			return false, nil, ErrInvalidListBannersFormat
+		}
+
+		if arg == "--list-banners" {
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return true, remaining, nil
+		}
+	}
+
+	// No list switch found
+	return false, args, nil
+}
+
+// ParsePreviewFlag extracts and validates the --preview flag
+// Returns: sample text (DefaultPreviewText for a bare --preview, empty when absent), remainingArgs, error
+func ParsePreviewFlag(args []string) (string, []string, error) {
+	for i, arg := range args {
+		text := DefaultPreviewText
+		if strings.HasPrefix(arg, "--preview=") {
+			text = strings.TrimPrefix(arg, "--preview=")
+			if text == "" {
+				return "", nil, ErrInvalidPreviewFormat
+			}
+		} else if arg != "--preview" {
+			continue
+		}
+
+		// Remove this arg from the list
+		remaining := append([]string{}, args[:i]...)
+		remaining = append(remaining, args[i+1:]...)
+
+		return text, remaining, nil
+	}
+
+	// No preview flag found
+	return "", args, nil
+}
@@ -0,0 +1,125 @@
+package ascii
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UsageBorder is the usage message for the border feature
+const UsageBorder = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --border=single "text" standard
+EX: go run ./cmd --border=rounded --padding=1,2 --margin=1 --align=center "text" shadow
+EX: go run ./cmd --border=custom:+-+||+-+ --border-color=red "text" thinkertoy`
+
+var (
+	// ErrInvalidBorderFormat is returned when a --border, --padding or --margin flag format is incorrect
+	ErrInvalidBorderFormat = fmt.Errorf("invalid border flag format\n%s", UsageBorder)
+)
+
+// WrapBorderError wraps an invalid border style error
+func WrapBorderError(border string) error {
+	return fmt.Errorf("invalid border: %s\nValid borders: ascii, single, double, rounded, heavy, custom:<8 characters>", border)
+}
+
+// WrapPaddingError wraps an invalid padding error
+func WrapPaddingError(padding string) error {
+	return fmt.Errorf("invalid padding: %s\nPadding is 1, 2 or 4 whole numbers, 0 or more: all, vertical,horizontal or top,right,bottom,left", padding)
+}
+
+// WrapMarginError wraps an invalid margin error
+func WrapMarginError(margin string) error {
+	return fmt.Errorf("invalid margin: %s\nMargin is 1, 2 or 4 whole numbers, 0 or more: all, vertical,horizontal or top,right,bottom,left", margin)
+}
+
+// ParseBorderFlags extracts and validates the --border, --padding and --margin flags
+// Returns: border (the zero Border when none are given), remainingArgs, error
+func ParseBorderFlags(args []string) (Border, []string, error) {
+	var border Border
+	var remaining []string
+
+	for _, arg := range args {
+		switch {
+		case arg == "--border" || arg == "--padding" || arg == "--margin":
+			// Malformed flag (missing =)
+			return Border{}, nil, ErrInvalidBorderFormat
+		case strings.HasPrefix(arg, "--border="):
+			value := strings.TrimPrefix(arg, "--border=")
+			if value == "" {
+				return Border{}, nil, ErrInvalidBorderFormat
+			}
+			chars, ok := parseBorderChars(value)
+			if !ok {
+				return Border{}, nil, WrapBorderError(value)
+			}
+			border.Chars = chars
+		case strings.HasPrefix(arg, "--padding="):
+			value := strings.TrimPrefix(arg, "--padding=")
+			if value == "" {
+				return Border{}, nil, ErrInvalidBorderFormat
+			}
+			box, ok := parseBox(value)
+			if !ok {
+				return Border{}, nil, WrapPaddingError(value)
+			}
+			border.Padding = box
+		case strings.HasPrefix(arg, "--margin="):
+			value := strings.TrimPrefix(arg, "--margin=")
+			if value == "" {
+				return Border{}, nil, ErrInvalidBorderFormat
+			}
+			box, ok := parseBox(value)
+			if !ok {
+				return Border{}, nil, WrapMarginError(value)
+			}
+			border.Margin = box
+		default:
+			// Keep non-border args
+			remaining = append(remaining, arg)
+		}
+	}
+
+	return border, remaining, nil
+}
+
+// parseBorderChars converts a border style, or custom: followed by 8 characters, into frame characters
+func parseBorderChars(value string) ([8]rune, bool) {
+	custom, isCustom := strings.CutPrefix(value, "custom:")
+	if !isCustom {
+		chars, ok := BorderNames[value]
+		return chars, ok
+	}
+
+	var chars [8]rune
+	if utf8.RuneCountInString(custom) != len(chars) || strings.IndexFunc(custom, unicode.IsControl) >= 0 {
+		return chars, false
+	}
+	copy(chars[:], []rune(custom))
+	return chars, true
+}
+
+// parseBox converts 1, 2 or 4 comma separated sizes into a Box, in the order CSS uses
+func parseBox(value string) (Box, bool) {
+	parts := strings.Split(value, ",")
+	sizes := make([]int, len(parts))
+	for i, part := range parts {
+		size, err := strconv.Atoi(part)
+		if err != nil || size < 0 {
+			return Box{}, false
+		}
+		sizes[i] = size
+	}
+
+	switch len(sizes) {
+	case 1:
+		return Box{Top: sizes[0], Right: sizes[0], Bottom: sizes[0], Left: sizes[0]}, true
+	case 2:
+		return Box{Top: sizes[0], Right: sizes[1], Bottom: sizes[0], Left: sizes[1]}, true
+	case 4:
+		return Box{Top: sizes[0], Right: sizes[1], Bottom: sizes[2], Left: sizes[3]}, true
+	}
+	return Box{}, false
+}
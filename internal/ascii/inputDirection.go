@@ -0,0 +1,55 @@
+package ascii
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UsageDirection is the usage message for the direction feature
+const UsageDirection = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --direction=vertical "text" standard
+EX: go run ./cmd --direction=vertical --align=center --color=red "x" "text" shadow`
+
+var (
+	// ErrInvalidDirectionFormat is returned when the --direction flag format is incorrect
+	ErrInvalidDirectionFormat = fmt.Errorf("invalid --direction flag format\n%s", UsageDirection)
+)
+
+// WrapDirectionError wraps an invalid direction error
+func WrapDirectionError(direction string) error {
+	return fmt.Errorf("invalid direction: %s\nValid directions: horizontal, vertical", direction)
+}
+
+// ParseDirectionFlag extracts and validates the --direction flag
+// Returns: direction (DirectionHorizontal when the flag is absent), remainingArgs, error
+func ParseDirectionFlag(args []string) (Direction, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--direction" {
+			return DirectionHorizontal, nil, ErrInvalidDirectionFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--direction=") {
+			name := strings.TrimPrefix(arg, "--direction=")
+			if name == "" {
+				return DirectionHorizontal, nil, ErrInvalidDirectionFormat
+			}
+
+			direction, ok := DirectionNames[name]
+			if !ok {
+				return DirectionHorizontal, nil, WrapDirectionError(name)
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return direction, remaining, nil
+		}
+	}
+
+	// No direction flag found
+	return DirectionHorizontal, args, nil
+}
@@ -0,0 +1,84 @@
+package ascii
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UsageEffect is the usage message for the effect feature
+const UsageEffect = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --effect=hollow --scale=2 "text" standard
+EX: go run ./cmd --effect=outline:double --fill=# "text" shadow
+EX: go run ./cmd --effect=outline:* --color=red "t" "text" thinkertoy`
+
+var (
+	// ErrInvalidEffectFormat is returned when the --effect flag format is incorrect
+	ErrInvalidEffectFormat = fmt.Errorf("invalid --effect flag format\n%s", UsageEffect)
+)
+
+// WrapEffectError wraps an invalid effect error
+func WrapEffectError(effect string) error {
+	return fmt.Errorf("invalid effect: %s\nValid effects: hollow, outline, outline:<character>, outline:<ascii|single|double|rounded|heavy>", effect)
+}
+
+// ParseEffectFlag extracts and validates the --effect flag
+// Returns: effect (the zero Effect when the flag is absent), remainingArgs, error
+func ParseEffectFlag(args []string) (Effect, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--effect" {
+			return Effect{}, nil, ErrInvalidEffectFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--effect=") {
+			value := strings.TrimPrefix(arg, "--effect=")
+			if value == "" {
+				return Effect{}, nil, ErrInvalidEffectFormat
+			}
+
+			effect, ok := parseEffect(value)
+			if !ok {
+				return Effect{}, nil, WrapEffectError(value)
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return effect, remaining, nil
+		}
+	}
+
+	// No effect flag found
+	return Effect{}, args, nil
+}
+
+// parseEffect converts the value of --effect into an Effect
+// An outline is drawn with single box drawing unless a set or a character follows the colon.
+func parseEffect(value string) (Effect, bool) {
+	name, style, hasStyle := strings.Cut(value, ":")
+	mode, ok := EffectNames[name]
+	if !ok || (hasStyle && mode != EffectOutline) {
+		return Effect{}, false
+	}
+	if mode != EffectOutline {
+		return Effect{Mode: mode}, true
+	}
+
+	if !hasStyle {
+		return Effect{Mode: mode, Box: OutlineSets["single"]}, true
+	}
+	if box, ok := OutlineSets[style]; ok {
+		return Effect{Mode: mode, Box: box}, true
+	}
+
+	ch, size := utf8.DecodeRuneInString(style)
+	if size == 0 || size != len(style) || unicode.IsSpace(ch) || unicode.IsControl(ch) {
+		return Effect{}, false
+	}
+	return Effect{Mode: mode, Char: ch}, true
+}
@@ -0,0 +1,107 @@
+package ascii
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// UsageFallback is the usage message for the missing-glyph fallback feature
+const UsageFallback = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+--fallback takes banner names to try in order, optionally ending with a policy:
+blank, skip, error, replace or replace:<char>
+
+EX: go run ./cmd --fallback=shadow,standard "text" thinkertoy
+EX: go run ./cmd --fallback=replace:# "text" standard`
+
+var (
+	// ErrInvalidFallbackFormat is returned when the --fallback flag format is incorrect
+	ErrInvalidFallbackFormat = fmt.Errorf("invalid --fallback flag format\n%s", UsageFallback)
+
+	// ErrMissingGlyph is returned by CheckGlyphs when a character cannot be drawn
+	ErrMissingGlyph = errors.New("missing glyph")
+)
+
+// WrapMissingGlyphError wraps ErrMissingGlyph with the character and banner involved
+func WrapMissingGlyphError(ch rune, banner string) error {
+	return fmt.Errorf("%w: %q (%U) is not in banner %s or its fallback fonts", ErrMissingGlyph, ch, ch, banner)
+}
+
+// WrapFallbackFontError wraps an unknown fallback banner error
+func WrapFallbackFontError(name string) error {
+	return fmt.Errorf("invalid fallback banner: %s\nValid banners: %s", name, strings.Join(Banners.Names(), ", "))
+}
+
+// ParseFallbackFlag extracts and validates the --fallback flag, loading the fallback fonts
+// Returns: fallback policy (the zero value when the flag is absent), remainingArgs, error
+func ParseFallbackFlag(args []string) (Fallback, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--fallback" {
+			return Fallback{}, nil, ErrInvalidFallbackFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--fallback=") {
+			value := strings.TrimPrefix(arg, "--fallback=")
+			if value == "" {
+				return Fallback{}, nil, ErrInvalidFallbackFormat
+			}
+
+			fallback, err := parseFallback(strings.Split(value, ","))
+			if err != nil {
+				return Fallback{}, nil, err
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return fallback, remaining, nil
+		}
+	}
+
+	// No fallback flag found, draw blank placeholders
+	return Fallback{}, args, nil
+}
+
+// parseFallback builds a policy from banner names optionally followed by a policy keyword
+func parseFallback(items []string) (Fallback, error) {
+	var fallback Fallback
+
+	for i, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return Fallback{}, ErrInvalidFallbackFormat
+		}
+
+		keyword, replacement, hasChar := strings.Cut(item, ":")
+		if mode, ok := FallbackModeNames[keyword]; ok {
+			// The policy keyword must come last
+			if i != len(items)-1 {
+				return Fallback{}, ErrInvalidFallbackFormat
+			}
+			if hasChar {
+				if mode != FallbackReplace || utf8.RuneCountInString(replacement) != 1 {
+					return Fallback{}, ErrInvalidFallbackFormat
+				}
+				fallback.Replacement, _ = utf8.DecodeRuneInString(replacement)
+			}
+			fallback.Mode = mode
+			continue
+		}
+
+		if !IsValidBanner(item) {
+			return Fallback{}, WrapFallbackFontError(item)
+		}
+		font, err := LoadBannerFile(item)
+		if err != nil {
+			return Fallback{}, err
+		}
+		fallback.Fonts = append(fallback.Fonts, font)
+	}
+
+	return fallback, nil
+}
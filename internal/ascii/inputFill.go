@@ -0,0 +1,76 @@
+package ascii
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UsageFill is the usage message for the fill feature
+const UsageFill = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --fill=# "text" standard
+EX: go run ./cmd --fill=texture:<> "text" shadow
+EX: go run ./cmd --fill=text --color=red "text" thinkertoy`
+
+var (
+	// ErrInvalidFillFormat is returned when the --fill flag format is incorrect
+	ErrInvalidFillFormat = fmt.Errorf("invalid --fill flag format\n%s", UsageFill)
+)
+
+// WrapFillError wraps an invalid fill error
+func WrapFillError(fill string) error {
+	return fmt.Errorf("invalid fill: %s\nValid fills: a single character, texture:<characters>, text", fill)
+}
+
+// ParseFillFlag extracts and validates the --fill flag
+// Returns: fill (the zero Fill when the flag is absent), remainingArgs, error
+func ParseFillFlag(args []string) (Fill, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--fill" {
+			return Fill{}, nil, ErrInvalidFillFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--fill=") {
+			value := strings.TrimPrefix(arg, "--fill=")
+			if value == "" {
+				return Fill{}, nil, ErrInvalidFillFormat
+			}
+
+			fill, err := parseFill(value)
+			if err != nil {
+				return Fill{}, nil, err
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return fill, remaining, nil
+		}
+	}
+
+	// No fill flag found
+	return Fill{}, args, nil
+}
+
+// parseFill converts the value of --fill into a Fill
+func parseFill(value string) (Fill, error) {
+	if value == "text" {
+		return Fill{Source: true}, nil
+	}
+
+	texture, isTexture := strings.CutPrefix(value, "texture:")
+	if !isTexture && utf8.RuneCountInString(value) != 1 {
+		return Fill{}, WrapFillError(value)
+	}
+
+	// A texture may leave holes with spaces, but it has to draw something
+	if strings.TrimSpace(texture) == "" || strings.IndexFunc(texture, unicode.IsControl) >= 0 {
+		return Fill{}, WrapFillError(value)
+	}
+	return Fill{Texture: []rune(texture)}, nil
+}
@@ -0,0 +1,56 @@
+package ascii
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UsageInvert is the usage message for the invert feature
+const UsageInvert = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --invert "text" standard
+EX: go run ./cmd --invert=# --align=center "text" shadow
+EX: go run ./cmd --invert --color=cyan "text" thinkertoy`
+
+var (
+	// ErrInvalidInvertFormat is returned when the --invert flag format is incorrect
+	ErrInvalidInvertFormat = fmt.Errorf("invalid --invert flag format\n%s", UsageInvert)
+)
+
+// WrapInvertError wraps an invalid invert character error
+func WrapInvertError(value string) error {
+	return fmt.Errorf("invalid invert character: %s\nThe background must be a single visible character", value)
+}
+
+// ParseInvertFlag extracts and validates the --invert flag
+// Returns: background character (DefaultInvertChar for a bare --invert, 0 when absent), remainingArgs, error
+func ParseInvertFlag(args []string) (rune, []string, error) {
+	for i, arg := range args {
+		ch := DefaultInvertChar
+		if strings.HasPrefix(arg, "--invert=") {
+			value := strings.TrimPrefix(arg, "--invert=")
+			if value == "" {
+				return 0, nil, ErrInvalidInvertFormat
+			}
+
+			r, size := utf8.DecodeRuneInString(value)
+			if size != len(value) || unicode.IsSpace(r) || unicode.IsControl(r) {
+				return 0, nil, WrapInvertError(value)
+			}
+			ch = r
+		} else if arg != "--invert" {
+			continue
+		}
+
+		// Remove this arg from the list
+		remaining := append([]string{}, args[:i]...)
+		remaining = append(remaining, args[i+1:]...)
+
+		return ch, remaining, nil
+	}
+
+	// No invert flag found
+	return 0, args, nil
+}
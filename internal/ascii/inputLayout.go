@@ -0,0 +1,74 @@
+package ascii
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UsageLayout is the usage message for the layout feature
+const UsageLayout = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --layout=smush "text" shadow
+EX: go run ./cmd --vlayout=fit "line one\nline two" standard`
+
+var (
+	// ErrInvalidLayoutFormat is returned when the --layout flag format is incorrect
+	ErrInvalidLayoutFormat = fmt.Errorf("invalid --layout flag format\n%s", UsageLayout)
+
+	// ErrInvalidVLayoutFormat is returned when the --vlayout flag format is incorrect
+	ErrInvalidVLayoutFormat = fmt.Errorf("invalid --vlayout flag format\n%s", UsageLayout)
+)
+
+// WrapLayoutTypeError wraps an invalid layout mode error
+func WrapLayoutTypeError(layout string) error {
+	return fmt.Errorf("invalid layout mode: %s\nValid modes: full, fit, smush", layout)
+}
+
+// WrapVLayoutTypeError wraps an invalid vertical layout mode error
+func WrapVLayoutTypeError(layout string) error {
+	return fmt.Errorf("invalid vertical layout mode: %s\nValid modes: full, fit, smush", layout)
+}
+
+// ParseLayoutFlag extracts and validates the --layout flag
+// Returns: layout (LayoutDefault when the flag is absent), remainingArgs, error
+func ParseLayoutFlag(args []string) (Layout, []string, error) {
+	return parseLayoutArg(args, "--layout", ErrInvalidLayoutFormat, WrapLayoutTypeError)
+}
+
+// ParseVLayoutFlag extracts and validates the --vlayout flag
+// Returns: vertical layout (LayoutDefault when the flag is absent), remainingArgs, error
+func ParseVLayoutFlag(args []string) (Layout, []string, error) {
+	return parseLayoutArg(args, "--vlayout", ErrInvalidVLayoutFormat, WrapVLayoutTypeError)
+}
+
+// parseLayoutArg extracts a layout mode flag named flag from args
+func parseLayoutArg(args []string, flag string, errFormat error, wrapType func(string) error) (Layout, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == flag {
+			return LayoutDefault, nil, errFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, flag+"=") {
+			name := strings.TrimPrefix(arg, flag+"=")
+			if name == "" {
+				return LayoutDefault, nil, errFormat
+			}
+
+			layout, ok := LayoutNames[name]
+			if !ok {
+				return LayoutDefault, nil, wrapType(name)
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return layout, remaining, nil
+		}
+	}
+
+	// No layout flag found, use the font's layout
+	return LayoutDefault, args, nil
+}
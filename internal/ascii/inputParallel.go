@@ -0,0 +1,61 @@
+package ascii
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// UsageWorkers is the usage message for the parallel rendering feature
+const UsageWorkers = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --workers=4 --input=big.log standard
+EX: go run ./cmd --workers=auto --input=big.log standard`
+
+var (
+	// ErrInvalidWorkersFormat is returned when the --workers flag format is incorrect
+	ErrInvalidWorkersFormat = fmt.Errorf("invalid --workers flag format\n%s", UsageWorkers)
+)
+
+// WrapWorkersError wraps an invalid worker count error
+func WrapWorkersError(workers string) error {
+	return fmt.Errorf("invalid worker count: %s\nWorkers must be a positive number or auto", workers)
+}
+
+// ParseWorkersFlag extracts and validates the --workers flag; auto uses one worker per CPU
+// Returns: worker count (0 when the flag is absent), remainingArgs, error
+func ParseWorkersFlag(args []string) (int, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--workers" {
+			return 0, nil, ErrInvalidWorkersFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--workers=") {
+			value := strings.TrimPrefix(arg, "--workers=")
+			if value == "" {
+				return 0, nil, ErrInvalidWorkersFormat
+			}
+
+			workers := runtime.NumCPU()
+			if value != "auto" {
+				n, err := strconv.Atoi(value)
+				if err != nil || n <= 0 {
+					return 0, nil, WrapWorkersError(value)
+				}
+				workers = n
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return workers, remaining, nil
+		}
+	}
+
+	// No workers flag found
+	return 0, args, nil
+}
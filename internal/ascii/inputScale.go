@@ -0,0 +1,69 @@
+package ascii
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UsageScale is the usage message for the scaling feature
+const UsageScale = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --scale=2 "text" standard
+EX: go run ./cmd --scale-x=2 --scale-y=1 "text" standard`
+
+var (
+	// ErrInvalidScaleFormat is returned when a --scale, --scale-x or --scale-y flag format is incorrect
+	ErrInvalidScaleFormat = fmt.Errorf("invalid --scale flag format\n%s", UsageScale)
+)
+
+// WrapScaleError wraps an invalid scale factor error
+func WrapScaleError(scale string) error {
+	return fmt.Errorf("invalid scale: %s\nScale must be a positive whole number", scale)
+}
+
+// ParseScaleFlags extracts and validates the --scale, --scale-x and --scale-y flags
+// --scale sets both factors; --scale-x and --scale-y override one of them
+// Returns: horizontal and vertical scale (0 when not given), remainingArgs, error
+func ParseScaleFlags(args []string) (int, int, []string, error) {
+	var both, x, y int
+	var remaining []string
+
+	for _, arg := range args {
+		var target *int
+		var value string
+
+		switch {
+		case arg == "--scale" || arg == "--scale-x" || arg == "--scale-y":
+			// Malformed flag (missing =)
+			return 0, 0, nil, ErrInvalidScaleFormat
+		case strings.HasPrefix(arg, "--scale="):
+			target, value = &both, strings.TrimPrefix(arg, "--scale=")
+		case strings.HasPrefix(arg, "--scale-x="):
+			target, value = &x, strings.TrimPrefix(arg, "--scale-x=")
+		case strings.HasPrefix(arg, "--scale-y="):
+			target, value = &y, strings.TrimPrefix(arg, "--scale-y=")
+		default:
+			// Keep non-scale args
+			remaining = append(remaining, arg)
+			continue
+		}
+
+		if value == "" {
+			return 0, 0, nil, ErrInvalidScaleFormat
+		}
+		scale, err := strconv.Atoi(value)
+		if err != nil || scale <= 0 {
+			return 0, 0, nil, WrapScaleError(value)
+		}
+		*target = scale
+	}
+
+	if x == 0 {
+		x = both
+	}
+	if y == 0 {
+		y = both
+	}
+	return x, y, remaining, nil
+}
@@ -0,0 +1,91 @@
+package ascii
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UsageShadow is the usage message for the shadow feature
+const UsageShadow = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --shadow=2,1,# "text" standard
+EX: go run ./cmd --extrude=3,1 --shadow-color=blue "text" thinkertoy`
+
+var (
+	// ErrInvalidShadowFormat is returned when a --shadow or --extrude flag format is incorrect
+	ErrInvalidShadowFormat = fmt.Errorf("invalid shadow flag format\n%s", UsageShadow)
+
+	// ErrShadowAndExtrude is returned when both --shadow and --extrude are given
+	ErrShadowAndExtrude = errors.New("--shadow and --extrude cannot be used together")
+)
+
+// WrapShadowError wraps an invalid shadow error
+func WrapShadowError(shadow string) error {
+	return fmt.Errorf("invalid shadow: %s\nA shadow is dx,dy or dx,dy,char: whole numbers of columns and rows, not both 0, and a character", shadow)
+}
+
+// ParseShadowFlags extracts and validates the --shadow and --extrude flags
+// Returns: shadow (the zero Shadow when neither is given), remainingArgs, error
+func ParseShadowFlags(args []string) (Shadow, []string, error) {
+	var shadow Shadow
+	var remaining []string
+	found := false
+
+	for _, arg := range args {
+		switch {
+		case arg == "--shadow" || arg == "--extrude":
+			// Malformed flag (missing =)
+			return Shadow{}, nil, ErrInvalidShadowFormat
+		case strings.HasPrefix(arg, "--shadow=") || strings.HasPrefix(arg, "--extrude="):
+			if found {
+				return Shadow{}, nil, ErrShadowAndExtrude
+			}
+			name, value, _ := strings.Cut(arg, "=")
+			if value == "" {
+				return Shadow{}, nil, ErrInvalidShadowFormat
+			}
+
+			var ok bool
+			shadow, ok = parseShadow(value)
+			if !ok {
+				return Shadow{}, nil, WrapShadowError(value)
+			}
+			shadow.Extrude = name == "--extrude"
+			found = true
+		default:
+			// Keep non-shadow args
+			remaining = append(remaining, arg)
+		}
+	}
+
+	return shadow, remaining, nil
+}
+
+// parseShadow converts dx,dy or dx,dy,char into a Shadow
+// The character is everything after the second comma, so it may itself be a comma.
+func parseShadow(value string) (Shadow, bool) {
+	parts := strings.SplitN(value, ",", 3)
+	if len(parts) < 2 {
+		return Shadow{}, false
+	}
+
+	dx, errX := strconv.Atoi(parts[0])
+	dy, errY := strconv.Atoi(parts[1])
+	if errX != nil || errY != nil || (dx == 0 && dy == 0) {
+		return Shadow{}, false
+	}
+	shadow := Shadow{DX: dx, DY: dy}
+
+	if len(parts) == 3 {
+		ch, size := utf8.DecodeRuneInString(parts[2])
+		if size == 0 || size != len(parts[2]) || unicode.IsSpace(ch) || unicode.IsControl(ch) {
+			return Shadow{}, false
+		}
+		shadow.Char = ch
+	}
+	return shadow, true
+}
@@ -0,0 +1,68 @@
+package ascii
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UsageSpacing is the usage message for the spacing feature
+const UsageSpacing = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --letter-spacing=2 "text" standard
+EX: go run ./cmd --letter-spacing=-1 --line-spacing=1 "line one\nline two" shadow`
+
+var (
+	// ErrInvalidSpacingFormat is returned when a --letter-spacing or --line-spacing flag format is incorrect
+	ErrInvalidSpacingFormat = fmt.Errorf("invalid spacing flag format\n%s", UsageSpacing)
+)
+
+// WrapLetterSpacingError wraps an invalid letter spacing error
+func WrapLetterSpacingError(spacing string) error {
+	return fmt.Errorf("invalid letter spacing: %s\nLetter spacing must be a whole number, negative to overlap", spacing)
+}
+
+// WrapLineSpacingError wraps an invalid line spacing error
+func WrapLineSpacingError(spacing string) error {
+	return fmt.Errorf("invalid line spacing: %s\nLine spacing must be a whole number of rows, 0 or more", spacing)
+}
+
+// ParseSpacingFlags extracts and validates the --letter-spacing and --line-spacing flags
+// Returns: letter spacing and line spacing (0 when not given), remainingArgs, error
+func ParseSpacingFlags(args []string) (int, int, []string, error) {
+	var letter, line int
+	var remaining []string
+
+	for _, arg := range args {
+		switch {
+		case arg == "--letter-spacing" || arg == "--line-spacing":
+			// Malformed flag (missing =)
+			return 0, 0, nil, ErrInvalidSpacingFormat
+		case strings.HasPrefix(arg, "--letter-spacing="):
+			value := strings.TrimPrefix(arg, "--letter-spacing=")
+			if value == "" {
+				return 0, 0, nil, ErrInvalidSpacingFormat
+			}
+			spacing, err := strconv.Atoi(value)
+			if err != nil {
+				return 0, 0, nil, WrapLetterSpacingError(value)
+			}
+			letter = spacing
+		case strings.HasPrefix(arg, "--line-spacing="):
+			value := strings.TrimPrefix(arg, "--line-spacing=")
+			if value == "" {
+				return 0, 0, nil, ErrInvalidSpacingFormat
+			}
+			spacing, err := strconv.Atoi(value)
+			if err != nil || spacing < 0 {
+				return 0, 0, nil, WrapLineSpacingError(value)
+			}
+			line = spacing
+		default:
+			// Keep non-spacing args
+			remaining = append(remaining, arg)
+		}
+	}
+
+	return letter, line, remaining, nil
+}
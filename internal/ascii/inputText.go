@@ -0,0 +1,133 @@
+package ascii
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UsageText is the usage message for the text normalisation flags
+const UsageText = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+Escapes: \n newline, \t tab, \\ backslash, \xNN and \u{NNNN} characters
+
+EX: go run ./cmd "Name:\tValue" standard
+EX: go run ./cmd --tab-width=8 "a\tb" standard
+EX: go run ./cmd --raw "C:\new" standard
+EX: go run ./cmd --control=error "text" standard`
+
+var (
+	// ErrInvalidRawFormat is returned when the --raw switch is given a value
+	ErrInvalidRawFormat = fmt.Errorf("invalid --raw flag format, --raw takes no value\n%s", UsageText)
+
+	// ErrInvalidTabWidthFormat is returned when the --tab-width flag format is incorrect
+	ErrInvalidTabWidthFormat = fmt.Errorf("invalid --tab-width flag format\n%s", UsageText)
+
+	// ErrInvalidControlFormat is returned when the --control flag format is incorrect
+	ErrInvalidControlFormat = fmt.Errorf("invalid --control flag format, use --control=strip or --control=error\n%s", UsageText)
+
+	// ErrInvalidEscape is returned for a malformed escape sequence
+	ErrInvalidEscape = errors.New("invalid escape sequence")
+
+	// ErrControlChar is returned for a control character under the ControlError mode
+	ErrControlChar = errors.New("control character in input")
+)
+
+// WrapEscapeError wraps ErrInvalidEscape with the offending sequence
+func WrapEscapeError(seq string) error {
+	return fmt.Errorf("%w: %s\nuse --raw to keep backslashes as typed\n%s", ErrInvalidEscape, seq, UsageText)
+}
+
+// WrapControlCharError wraps ErrControlChar with the offending character
+func WrapControlCharError(ch rune) error {
+	return fmt.Errorf("%w: %U", ErrControlChar, ch)
+}
+
+// WrapTabWidthError wraps an invalid tab width value error
+func WrapTabWidthError(width string) error {
+	return fmt.Errorf("invalid tab width: %s\nTab width must be a positive number of characters", width)
+}
+
+// ParseRawFlag extracts the --raw switch
+// Returns: whether escapes are left as typed, remainingArgs, error
+func ParseRawFlag(args []string) (bool, []string, error) {
+	for i, arg := range args {
+		// The switch takes no value
+		if strings.HasPrefix(arg, "--raw=") {
+			return false, nil, ErrInvalidRawFormat
+		}
+
+		if arg == "--raw" {
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return true, remaining, nil
+		}
+	}
+
+	// No raw switch found
+	return false, args, nil
+}
+
+// ParseTabWidthFlag extracts and validates the --tab-width flag
+// Returns: tab width (0 when the flag is absent), remainingArgs, error
+func ParseTabWidthFlag(args []string) (int, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--tab-width" {
+			return 0, nil, ErrInvalidTabWidthFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--tab-width=") {
+			value := strings.TrimPrefix(arg, "--tab-width=")
+			if value == "" {
+				return 0, nil, ErrInvalidTabWidthFormat
+			}
+
+			width, err := strconv.Atoi(value)
+			if err != nil || width <= 0 {
+				return 0, nil, WrapTabWidthError(value)
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return width, remaining, nil
+		}
+	}
+
+	// No tab width flag found
+	return 0, args, nil
+}
+
+// ParseControlFlag extracts and validates the --control flag
+// Returns: control mode (ControlStrip when the flag is absent), remainingArgs, error
+func ParseControlFlag(args []string) (ControlMode, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--control" {
+			return ControlStrip, nil, ErrInvalidControlFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--control=") {
+			mode, ok := ControlModeNames[strings.TrimPrefix(arg, "--control=")]
+			if !ok {
+				return ControlStrip, nil, ErrInvalidControlFormat
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return mode, remaining, nil
+		}
+	}
+
+	// No control flag found
+	return ControlStrip, args, nil
+}
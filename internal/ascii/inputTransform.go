@@ -0,0 +1,55 @@
+package ascii
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UsageTransform is the usage message for the transform feature
+const UsageTransform = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --transform=mirror "text" standard
+EX: go run ./cmd --transform=rotate90 --color=red "text" shadow`
+
+var (
+	// ErrInvalidTransformFormat is returned when the --transform flag format is incorrect
+	ErrInvalidTransformFormat = fmt.Errorf("invalid --transform flag format\n%s", UsageTransform)
+)
+
+// WrapTransformError wraps an invalid transform error
+func WrapTransformError(transform string) error {
+	return fmt.Errorf("invalid transform: %s\nValid transforms: mirror, flip, rotate90, rotate180, rotate270", transform)
+}
+
+// ParseTransformFlag extracts and validates the --transform flag
+// Returns: transform (TransformNone when the flag is absent), remainingArgs, error
+func ParseTransformFlag(args []string) (Transform, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--transform" {
+			return TransformNone, nil, ErrInvalidTransformFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--transform=") {
+			name := strings.TrimPrefix(arg, "--transform=")
+			if name == "" {
+				return TransformNone, nil, ErrInvalidTransformFormat
+			}
+
+			transform, ok := TransformNames[name]
+			if !ok {
+				return TransformNone, nil, WrapTransformError(name)
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return transform, remaining, nil
+		}
+	}
+
+	// No transform flag found
+	return TransformNone, args, nil
+}
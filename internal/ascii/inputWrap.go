@@ -0,0 +1,92 @@
+package ascii
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UsageWrap is the usage message for the wrapping feature
+const UsageWrap = `Usage: go run ./cmd [OPTION] [STRING] [BANNER]
+
+EX: go run ./cmd --width=60 "a long line of text" standard
+EX: go run ./cmd --width=40 --hyphenate=on "Supercalifragilistic" standard`
+
+var (
+	// ErrInvalidWidthFormat is returned when the --width flag format is incorrect
+	ErrInvalidWidthFormat = fmt.Errorf("invalid --width flag format\n%s", UsageWrap)
+
+	// ErrInvalidHyphenateFormat is returned when the --hyphenate flag format is incorrect
+	ErrInvalidHyphenateFormat = fmt.Errorf("invalid --hyphenate flag format, use --hyphenate=on or --hyphenate=off\n%s", UsageWrap)
+)
+
+// WrapWidthError wraps an invalid width value error
+func WrapWidthError(width string) error {
+	return fmt.Errorf("invalid width: %s\nWidth must be a positive number of columns", width)
+}
+
+// ParseWidthFlag extracts and validates the --width flag
+// Returns: width (0 when the flag is absent), remainingArgs, error
+func ParseWidthFlag(args []string) (int, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--width" {
+			return 0, nil, ErrInvalidWidthFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--width=") {
+			value := strings.TrimPrefix(arg, "--width=")
+			if value == "" {
+				return 0, nil, ErrInvalidWidthFormat
+			}
+
+			width, err := strconv.Atoi(value)
+			if err != nil || width <= 0 {
+				return 0, nil, WrapWidthError(value)
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return width, remaining, nil
+		}
+	}
+
+	// No width flag found
+	return 0, args, nil
+}
+
+// ParseHyphenateFlag extracts and validates the --hyphenate flag
+// Returns: whether to hyphenate (false when the flag is absent), remainingArgs, error
+func ParseHyphenateFlag(args []string) (bool, []string, error) {
+	for i, arg := range args {
+		// Check for malformed flag (missing =)
+		if arg == "--hyphenate" {
+			return false, nil, ErrInvalidHyphenateFormat
+		}
+
+		// Check for properly formatted flag
+		if strings.HasPrefix(arg, "--hyphenate=") {
+			var hyphenate bool
+			switch strings.TrimPrefix(arg, "--hyphenate=") {
+			case "on":
+				hyphenate = true
+			case "off":
+				hyphenate = false
+			default:
+				return false, nil, ErrInvalidHyphenateFormat
+			}
+
+			// Remove this arg from the list
+			remaining := append([]string{}, args[:i]...)
+			remaining = append(remaining, args[i+1:]...)
+
+			return hyphenate, remaining, nil
+		}
+	}
+
+	// No hyphenate flag found
+	return false, args, nil
+}
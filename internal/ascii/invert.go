@@ -0,0 +1,88 @@
+package ascii
+
+// DefaultInvertChar is the background drawn by --invert when no character is given
+const DefaultInvertChar = '█'
+
+// invert swaps the ink and the background of c inside the bounding box of its ink
+// The box runs from the first column with ink to the end of the widest row, over every row, so
+// it follows the real rendered width. Ink becomes blank and everything else becomes ch.
+// Background cells with no source take one from their neighbours, so colors cover the whole box.
+func invert(c *Canvas, ch rune) *Canvas {
+	if ch == 0 {
+		return c
+	}
+
+	left, width := -1, c.Width()
+	for _, row := range c.Rows {
+		for col, cell := range row {
+			if cell.Ch != ' ' && (left < 0 || col < left) {
+				left = col
+			}
+		}
+	}
+	if left < 0 {
+		return c // no ink, nothing to knock out
+	}
+
+	rows := make([][]Cell, len(c.Rows))
+	for r, row := range c.Rows {
+		rows[r] = append(append([]Cell{}, row...), blankRow(width-len(row))...)
+		for col := left; col < width; col++ {
+			if rows[r][col].Ch == ' ' {
+				rows[r][col].Ch = ch
+			} else {
+				rows[r][col].Ch = ' '
+			}
+		}
+	}
+	inheritSources(rows, left)
+	return &Canvas{Rows: rows}
+}
+
+// inheritSources gives the cells from column left that have no source the source of a neighbour
+// A cell takes the nearest source before it in its row, or after it at the start of the row;
+// a row with no source at all takes the sources of the nearest row that has them.
+func inheritSources(rows [][]Cell, left int) {
+	for _, row := range rows {
+		last := -1
+		for col := left; col < len(row); col++ {
+			if row[col].Src >= 0 {
+				last = row[col].Src
+			} else {
+				row[col].Src = last
+			}
+		}
+		next := -1
+		for col := len(row) - 1; col >= left; col-- {
+			if row[col].Src >= 0 {
+				next = row[col].Src
+			} else {
+				row[col].Src = next
+			}
+		}
+	}
+
+	for r := 1; r < len(rows); r++ {
+		adoptSources(rows[r], rows[r-1], left)
+	}
+	for r := len(rows) - 2; r >= 0; r-- {
+		adoptSources(rows[r], rows[r+1], left)
+	}
+}
+
+// adoptSources gives the cells of row from column left that have no source the source of the cell in from
+func adoptSources(row, from []Cell, left int) {
+	for col := left; col < len(row) && col < len(from); col++ {
+		if row[col].Src < 0 {
+			row[col].Src = from[col].Src
+		}
+	}
+}
+
+// InvertLines returns lines with the ink and background swapped, like --invert does to rendered art
+func InvertLines(lines []string, ch rune) []string {
+	if ch == 0 {
+		return lines
+	}
+	return invert(linesCanvas(lines), ch).Lines()
+}
@@ -0,0 +1,360 @@
+package ascii
+
+import (
+	"strings"
+)
+
+// Layout selects how glyphs are placed next to each other
+type Layout int
+
+const (
+	LayoutDefault Layout = iota // Use the layout from the font header
+	LayoutFull                  // Full width: glyphs are concatenated edge to edge
+	LayoutFit                   // Fitting (kerning): glyphs slide together until they touch
+	LayoutSmush                 // Smushing: glyphs overlap by one column where the rules allow it
+)
+
+// Horizontal smushing rules and layout bits, matching the FIGlet full_layout field
+const (
+	SmushEqual      = 1   // Rule 1: two equal characters merge into one
+	SmushUnderscore = 2   // Rule 2: an underscore is replaced by |/\[]{}()<>
+	SmushHierarchy  = 4   // Rule 3: the character from the later class wins: | /\ [] {} () <>
+	SmushPair       = 8   // Rule 4: opposite brackets merge into |
+	SmushBigX       = 16  // Rule 5: /\ becomes |, \/ becomes Y, >< becomes X
+	SmushHardblank  = 32  // Rule 6: two hardblanks merge into one
+	LayoutFitBit    = 64  // Horizontal fitting
+	LayoutSmushBit  = 128 // Horizontal smushing
+
+	// SmushAllRules enables the six classic rules
+	SmushAllRules = SmushEqual | SmushUnderscore | SmushHierarchy | SmushPair | SmushBigX | SmushHardblank
+)
+
+// Vertical smushing rules and layout bits, matching the FIGlet full_layout field
+const (
+	VSmushEqual      = 256   // Rule 1: two equal characters merge into one
+	VSmushUnderscore = 512   // Rule 2: an underscore is replaced by |/\[]{}()<>
+	VSmushHierarchy  = 1024  // Rule 3: the character from the later class wins
+	VSmushHorizontal = 2048  // Rule 4: - over _ or _ over - becomes =
+	VSmushVertical   = 4096  // Rule 5: | over | merges into one |
+	LayoutVFitBit    = 8192  // Vertical fitting
+	LayoutVSmushBit  = 16384 // Vertical smushing
+
+	// VSmushAllRules enables the five vertical rules
+	VSmushAllRules = VSmushEqual | VSmushUnderscore | VSmushHierarchy | VSmushHorizontal | VSmushVertical
+)
+
+// LayoutNames maps the names accepted by --layout to layout modes
+var LayoutNames = map[string]Layout{
+	"full":  LayoutFull,
+	"fit":   LayoutFit,
+	"smush": LayoutSmush,
+}
+
+// Options controls how text is laid out by Render
+// The zero value renders with the layouts stored in the font
+type Options struct {
+	Layout   Layout   // Horizontal layout mode
+	VLayout  Layout   // Vertical layout mode between lines of text
+	Fallback Fallback // Policy for characters missing from the font
+
+	Width     int  // Maximum width of the art in columns, border included, 0 to never wrap
+	Hyphenate bool // Draw a hyphen where a word is broken to fit Width
+
+	ScaleX int // Times each cell is repeated across, 0 or 1 for none
+	ScaleY int // Times each row is repeated down, 0 or 1 for none
+
+	Workers int // Lines rendered at once by Render and streamed input, 0 or 1 for serial
+
+	LetterSpacing int // Extra columns between glyphs, negative to overlap where columns are blank
+	LineSpacing   int // Blank rows between lines of text
+
+	Direction Direction // Whether characters run across or down
+	Transform Transform // Mirror, flip or rotate the finished block
+
+	Fill   Fill   // Characters drawn in place of the banner's ink
+	Effect Effect // Outline or hollow effect on the finished block
+	Invert rune   // Background drawn where the ink is knocked out of the finished block, 0 for none
+	Shadow Shadow // Shadow or extrusion drawn behind the finished block
+	Border Border // Frame, padding and margin around the finished block
+}
+
+// Scale returns the horizontal and vertical scale factors, at least 1
+func (o Options) Scale() (int, int) {
+	return max(o.ScaleX, 1), max(o.ScaleY, 1)
+}
+
+// WholeBlock reports whether the options change the finished block as a whole
+// A transform, an effect, inversion, a shadow or a border needs every row, so nothing is final until the block is.
+func (o Options) WholeBlock() bool {
+	return o.Transform != TransformNone || o.Effect.Mode != EffectNone || o.Invert != 0 || !o.Shadow.IsZero() || !o.Border.IsZero()
+}
+
+// InnerWidth returns the width left for the art when the finished block must fit in width columns
+// A shadow and a border take columns of their own. A width of 0 or less means no limit and is returned unchanged.
+func (o Options) InnerWidth(width int) int {
+	if width <= 0 {
+		return width
+	}
+	shadow, _ := o.Shadow.Size()
+	border, _ := o.Border.Size()
+	return max(width-shadow-border, 1)
+}
+
+// Finish applies the transform, effect, inversion, shadow and border to the finished block, in that order
+func (o Options) Finish(c *Canvas) *Canvas {
+	c = o.Effect.Apply(o.Transform.Apply(c))
+	return o.Border.Apply(o.Shadow.Apply(invert(c, o.Invert)))
+}
+
+// HorizontalLayout returns the layout mode and smushing rules stored in the font header
+func (f *Font) HorizontalLayout() (Layout, int) {
+	rules := f.FullLayout & SmushAllRules
+
+	switch {
+	case f.FullLayout&LayoutSmushBit != 0:
+		return LayoutSmush, rules
+	case f.FullLayout&LayoutFitBit != 0:
+		return LayoutFit, rules
+	default:
+		return LayoutFull, rules
+	}
+}
+
+// SetHorizontalLayout stores a layout mode in the font header, keeping its smushing rules
+func (f *Font) SetHorizontalLayout(mode Layout) {
+	f.FullLayout &^= LayoutFitBit | LayoutSmushBit
+
+	switch mode {
+	case LayoutFit:
+		f.FullLayout |= LayoutFitBit
+	case LayoutSmush:
+		f.FullLayout |= LayoutSmushBit
+	}
+}
+
+// horizontalLayout resolves the layout mode and smushing rules used to render with font
+// Smushing requested on a font without rules uses the six classic rules
+func (o Options) horizontalLayout(font *Font) (Layout, int) {
+	mode, rules := font.HorizontalLayout()
+
+	switch o.Layout {
+	case LayoutDefault:
+		return mode, rules
+	case LayoutSmush:
+		if rules == 0 {
+			rules = SmushAllRules
+		}
+		return LayoutSmush, rules
+	default:
+		return o.Layout, rules
+	}
+}
+
+// fullLayoutFromOld converts the old_layout header value to a full_layout value
+func fullLayoutFromOld(oldLayout int) int {
+	switch {
+	case oldLayout < 0:
+		return 0
+	case oldLayout == 0:
+		return LayoutFitBit
+	default:
+		return (oldLayout & SmushAllRules) | LayoutSmushBit
+	}
+}
+
+// smusher merges glyph columns according to a layout mode and its rules
+type smusher struct {
+	mode      Layout
+	rules     int
+	hardblank rune
+	spacing   int // Letter spacing added to the layout, see spacedOverlap
+}
+
+// smush returns the character produced by overlapping left and right, or 0 if they cannot merge
+// prevWidth and currWidth are the widths of the glyphs involved
+func (s smusher) smush(left, right rune, prevWidth, currWidth int) rune {
+	if left == ' ' {
+		return right
+	}
+	if right == ' ' {
+		return left
+	}
+
+	// Very narrow glyphs never smush
+	if prevWidth < 2 || currWidth < 2 {
+		return 0
+	}
+
+	if s.mode != LayoutSmush {
+		return 0
+	}
+
+	// Universal smushing: the right character wins over anything but a hardblank
+	if s.rules == 0 {
+		if left == s.hardblank {
+			return right
+		}
+		if right == s.hardblank {
+			return left
+		}
+		return right
+	}
+
+	if s.rules&SmushHardblank != 0 && left == s.hardblank && right == s.hardblank {
+		return left
+	}
+
+	if left == s.hardblank || right == s.hardblank {
+		return 0
+	}
+
+	if s.rules&SmushEqual != 0 && left == right {
+		return left
+	}
+
+	if s.rules&SmushUnderscore != 0 {
+		if left == '_' && strings.ContainsRune(`|/\[]{}()<>`, right) {
+			return right
+		}
+		if right == '_' && strings.ContainsRune(`|/\[]{}()<>`, left) {
+			return left
+		}
+	}
+
+	if s.rules&SmushHierarchy != 0 {
+		if winner := hierarchyWinner(left, right); winner != 0 {
+			return winner
+		}
+	}
+
+	if s.rules&SmushPair != 0 {
+		pair := string([]rune{left, right})
+		if pair == "[]" || pair == "][" || pair == "{}" || pair == "}{" || pair == "()" || pair == ")(" {
+			return '|'
+		}
+	}
+
+	if s.rules&SmushBigX != 0 {
+		switch {
+		case left == '/' && right == '\\':
+			return '|'
+		case left == '\\' && right == '/':
+			return 'Y'
+		case left == '>' && right == '<':
+			return 'X'
+		}
+	}
+
+	return 0
+}
+
+// hierarchyClasses lists the rule 3 character classes from lowest to highest
+var hierarchyClasses = []string{"|", `/\`, "[]", "{}", "()", "<>"}
+
+// hierarchyWinner returns the character from the higher class when left and right are in different classes
+func hierarchyWinner(left, right rune) rune {
+	leftClass, rightClass := -1, -1
+	for i, class := range hierarchyClasses {
+		if strings.ContainsRune(class, left) {
+			leftClass = i
+		}
+		if strings.ContainsRune(class, right) {
+			rightClass = i
+		}
+	}
+
+	if leftClass < 0 || rightClass < 0 || leftClass == rightClass {
+		return 0
+	}
+	if rightClass > leftClass {
+		return right
+	}
+	return left
+}
+
+// overlap returns how many columns glyph can slide into the rows already laid out
+// All rows must have the same length; the result never exceeds the glyph width
+func (s smusher) overlap(rows [][]Cell, glyph [][]Cell, prevWidth int) int {
+	if s.mode == LayoutFull || len(rows) == 0 {
+		return 0
+	}
+
+	currWidth := 0
+	if len(glyph) > 0 {
+		currWidth = len(glyph[0])
+	}
+
+	amount := currWidth
+	for row := range rows {
+		line := rows[row]
+		var chars []Cell
+		if row < len(glyph) {
+			chars = glyph[row]
+		}
+
+		// Rightmost ink in the laid out row; ink further left than the glyph is wide cannot limit it
+		lineEdge := len(line) - 1
+		floor := max(lineEdge-currWidth, 0)
+		for lineEdge > floor && line[lineEdge].Ch == ' ' {
+			lineEdge--
+		}
+
+		// Leftmost ink in the glyph row
+		charEdge := 0
+		for charEdge < len(chars) && chars[charEdge].Ch == ' ' {
+			charEdge++
+		}
+
+		rowAmount := charEdge + len(line) - 1 - lineEdge
+		if lineEdge < 0 || line[lineEdge].Ch == ' ' {
+			rowAmount++
+		} else if charEdge < len(chars) && s.smush(line[lineEdge].Ch, chars[charEdge].Ch, prevWidth, currWidth) != 0 {
+			rowAmount++
+		}
+
+		if rowAmount < amount {
+			amount = rowAmount
+		}
+	}
+
+	if amount < 0 {
+		return 0
+	}
+	return amount
+}
+
+// place appends glyph to rows, overlapping the last amount columns
+func (s smusher) place(rows [][]Cell, glyph [][]Cell, amount, prevWidth int) [][]Cell {
+	currWidth := 0
+	if len(glyph) > 0 {
+		currWidth = len(glyph[0])
+	}
+
+	for row := range rows {
+		chars := glyph[row]
+		line := rows[row]
+
+		for k := 0; k < amount && k < len(chars); k++ {
+			column := len(line) - amount + k
+			if column < 0 {
+				continue
+			}
+
+			left, right := line[column], chars[k]
+			merged := s.smush(left.Ch, right.Ch, prevWidth, currWidth)
+			if merged == 0 || merged == right.Ch {
+				// Smushing is only attempted where overlap allowed it, so fall back to the right cell
+				line[column] = right
+			} else if merged != left.Ch {
+				line[column] = Cell{Ch: merged, Src: right.Src}
+			}
+		}
+
+		if amount < len(chars) {
+			line = append(line, chars[amount:]...)
+		}
+		rows[row] = line
+	}
+
+	return rows
+}
@@ -1,37 +1,258 @@
 package ascii
 
 import (
-	"ascii-art/internal/files"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
-func LoadBannerFile(stylename string) (map[rune][]string, error) {
-	// Set the banner file path
-	path := "banners/" + stylename + ".txt"
+// BannerDir is the directory holding banner files that override the built-in ones
+const BannerDir = "banners"
 
-	// Read all lines from the file
-	lines, err := files.ReadBannerLines(path)
-	if err != nil {
-		return nil, err
+// ErrMalformedBanner is returned for a banner file that does not start with a blank space glyph
+// or holds fewer than the 95 printable ASCII glyphs
+var ErrMalformedBanner = errors.New("malformed")
+
+// LoadBannerFile loads a banner by name into a Font
+// stylename may be a banner name such as "standard", a font in the banners
+// directory such as "big" for banners/big.flf, or a path to a FIGlet .flf or TOIlet .tlf file.
+// Names are looked up on the Banners search path, so banners on disk override the built-in ones.
+func LoadBannerFile(stylename string) (*Font, error) {
+	// A FIGlet or TOIlet font can be given directly as a file path
+	if isFontPath(stylename) {
+		return LoadFigletFile(stylename)
 	}
 
-	// Create a map of runes to lines
-	bannerMap := make(map[rune][]string)
-	currentRune := rune(32) // Start at ASCII 32(' ')
+	// Search the banner path in order
+	return Banners.Load(stylename)
+}
+
+// IsValidBanner reports whether name refers to a banner on the search path or a font file that exists
+func IsValidBanner(name string) bool {
+	return isFontPath(name) || Banners.Has(name)
+}
+
+// isFontPath reports whether name is the path of an existing FIGlet or TOIlet font file
+func isFontPath(name string) bool {
+	if !IsFigletFile(name) {
+		return false
+	}
+
+	info, err := os.Stat(name)
+	return err == nil && !info.IsDir()
+}
+
+// ParseBanner builds a Font from the lines of a banner file
+// The file may start with optional "# key: value" header lines (name, author, description, height, layout, vlayout).
+// Glyphs follow in blocks of Height lines separated by one blank line, starting at rune 32 (' ').
+// When no height is given it is taken from the first glyph block, which must be the blank space glyph.
+// All 95 glyphs from ' ' to '~' must be present, otherwise the banner is malformed.
+// After the 95 classic glyphs an optional extended section defines any other character,
+// each block preceded by its codepoint, e.g. "U+00E9". Trailing text that does not start
+// with a codepoint is not an extended section and is ignored.
+func ParseBanner(name string, lines []string) (*Font, error) {
+	return parseBanner(name, lines, true)
+}
+
+// ParsePartialBanner builds a Font like ParseBanner, but keeps whatever glyphs the lines hold
+// Reverse templates use it, so art can be read back with a banner that only covers part of ASCII.
+func ParsePartialBanner(name string, lines []string) (*Font, error) {
+	return parseBanner(name, lines, false)
+}
+
+// parseBanner builds a Font from the lines of a banner file, checking it is a whole classic banner when strict
+func parseBanner(name string, lines []string, strict bool) (*Font, error) {
+	font := &Font{Name: name}
 	i := 0
 
+	// Read the optional metadata header
+	for i < len(lines) {
+		key, value, ok := parseHeaderLine(lines[i])
+		if !ok {
+			break
+		}
+		switch key {
+		case "name":
+			font.Name = value
+		case "author":
+			font.Author = value
+		case "description":
+			font.Description = value
+		case "height":
+			height, err := strconv.Atoi(value)
+			if err != nil || height <= 0 {
+				return nil, fmt.Errorf("banner %s: invalid height %q", name, value)
+			}
+			font.Height = height
+		case "layout":
+			mode, ok := LayoutNames[value]
+			if !ok {
+				return nil, fmt.Errorf("banner %s: invalid layout %q", name, value)
+			}
+			font.SetHorizontalLayout(mode)
+			if mode == LayoutSmush {
+				font.FullLayout |= SmushAllRules
+			}
+		case "vlayout":
+			mode, ok := LayoutNames[value]
+			if !ok {
+				return nil, fmt.Errorf("banner %s: invalid vlayout %q", name, value)
+			}
+			font.SetVerticalLayout(mode)
+			if mode == LayoutSmush {
+				font.FullLayout |= VSmushAllRules
+			}
+		}
+		i++
+	}
+
 	// Skip leading empty lines (padding at top)
 	for i < len(lines) && lines[i] == "" {
 		i++
 	}
 
-	// Loop through file in blocks of 9 (8 content + 1 seperator)
-	for i+8 <= len(lines) && currentRune <= 126 {
-		charLines := lines[i : i+8] // Get 8 block lines
-		bannerMap[currentRune] = charLines // Store those lines under currentRune count
-		i += 9 // Skip the seperator line // Skip the seperator after each character
+	if font.Height == 0 {
+		font.Height = detectHeight(lines[i:])
+	}
+	if font.Height == 0 {
+		return nil, fmt.Errorf("banner %s: no glyphs found", name)
+	}
+
+	// A classic banner starts with the blank space glyph, anything else is not a banner
+	if strict && (i+font.Height > len(lines) || !blankRows(lines[i:i+font.Height])) {
+		return nil, fmt.Errorf("banner %s: %w", name, ErrMalformedBanner)
+	}
+
+	currentRune := rune(32) // Start at ASCII 32(' ')
+
+	// Loop through file in blocks of Height content lines
+	for i+font.Height <= len(lines) && currentRune <= 126 {
+		font.SetGlyph(currentRune, lines[i:i+font.Height]) // Store the block under currentRune
+		i += font.Height
+
+		// Skip the separator after each character
+		if i < len(lines) && lines[i] == "" {
+			i++
+		}
 		currentRune++ // Move to next character rune count
 	}
+	if strict && currentRune <= 126 {
+		return nil, fmt.Errorf("banner %s: %w", name, ErrMalformedBanner)
+	}
+
+	// Optional extended section: each glyph block follows a "U+XXXX" codepoint header
+	// Older banners may have notes after the last glyph; unless they start with a header they are left alone.
+	for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+		i++
+	}
+	if i < len(lines) {
+		if _, ok := parseCodepoint(lines[i]); !ok {
+			return font, nil
+		}
+	}
+	for i < len(lines) {
+		if strings.TrimSpace(lines[i]) == "" {
+			i++
+			continue
+		}
 
-	return bannerMap, nil
+		ch, ok := parseCodepoint(lines[i])
+		if !ok {
+			return nil, fmt.Errorf("banner %s: line %d: expected a U+XXXX codepoint header, got %q", name, i+1, lines[i])
+		}
+		i++
+
+		if i+font.Height > len(lines) {
+			return nil, fmt.Errorf("banner %s: glyph %U needs %d rows", name, ch, font.Height)
+		}
+		font.SetGlyph(ch, lines[i:i+font.Height])
+		i += font.Height
+	}
+
+	return font, nil
+}
+
+// parseCodepoint reads a "U+XXXX" header, optionally followed by a description
+func parseCodepoint(line string) (rune, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	code := fields[0]
+	if len(code) < 3 || !strings.EqualFold(code[:2], "U+") {
+		return 0, false
+	}
+
+	value, err := strconv.ParseUint(code[2:], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(value)) {
+		return 0, false
+	}
+
+	return rune(value), true
+}
+
+// headerKeys are the metadata keys recognised at the top of a banner file
+var headerKeys = map[string]bool{
+	"name":        true,
+	"author":      true,
+	"description": true,
+	"height":      true,
+	"layout":      true,
+	"vlayout":     true,
+}
+
+// parseHeaderLine splits a "# key: value" header line
+func parseHeaderLine(line string) (string, string, bool) {
+	if !strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+
+	key, value, found := strings.Cut(strings.TrimPrefix(line, "#"), ":")
+	if !found {
+		return "", "", false
+	}
+
+	key = strings.ToLower(strings.TrimSpace(key))
+	if !headerKeys[key] {
+		return "", "", false
+	}
+
+	return key, strings.TrimSpace(value), true
+}
+
+// blankRows reports whether every row is empty or spaces
+func blankRows(rows []string) bool {
+	for _, row := range rows {
+		if strings.TrimSpace(row) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// detectHeight infers the glyph height from the first glyph block
+// The block ends at the first blank separator line. The first glyph of a banner is
+// the space character, so a switch from blank rows to ink also ends the block.
+func detectHeight(lines []string) int {
+	height := 0
+	blank := true
+
+	for _, line := range lines {
+		if line == "" {
+			break
+		}
+
+		isBlank := strings.TrimSpace(line) == ""
+		if height > 0 && blank && !isBlank {
+			break
+		}
+		blank = blank && isBlank
+		height++
+	}
 
+	return height
 }
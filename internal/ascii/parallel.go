@@ -0,0 +1,152 @@
+package ascii
+
+import (
+	"strings"
+	"sync"
+)
+
+// ParallelStream renders lines of input on a bounded pool of workers and stacks them in input order
+// Lines are independent until they are stacked, so only the rendering is spread over the workers.
+// The rows each line finalises are passed to emit, in input order, as soon as every earlier line is done.
+type ParallelStream struct {
+	stream *Stream
+	emit   func(line string, canvas *Canvas) error
+
+	jobs    chan parallelLine // Lines waiting for a worker
+	results chan parallelLine // Rendered lines, in any order
+	slots   chan struct{}     // Bounds the lines in flight, and so the memory held
+	done    chan struct{}     // Closed once every rendered line has been stacked
+
+	seq    int // Sequence number of the next line added
+	offset int // Byte offset of the next line added
+
+	mu  sync.Mutex
+	err error // First error returned by emit
+}
+
+// parallelLine is a line of input on its way through the pool
+type parallelLine struct {
+	seq      int
+	line     string
+	offset   int
+	rendered renderedLine
+}
+
+// NewParallelStream starts workers goroutines that render with font and opts
+// emit is called from a single goroutine; after an error it is not called again.
+func NewParallelStream(font *Font, opts Options, workers int, emit func(line string, canvas *Canvas) error) *ParallelStream {
+	if workers < 1 {
+		workers = 1
+	}
+
+	p := &ParallelStream{
+		stream:  NewStream(font, opts),
+		emit:    emit,
+		jobs:    make(chan parallelLine, workers),
+		results: make(chan parallelLine, workers),
+		slots:   make(chan struct{}, 4*workers),
+		done:    make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range p.jobs {
+				job.rendered = p.stream.render(job.line, job.offset)
+				p.results <- job
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(p.results)
+	}()
+	go p.collect()
+
+	return p
+}
+
+// collect stacks rendered lines in input order and hands their rows to emit
+func (p *ParallelStream) collect() {
+	defer close(p.done)
+
+	pending := make(map[int]parallelLine)
+	next := 0
+	for result := range p.results {
+		pending[result.seq] = result
+
+		// Release every line whose predecessors are all done
+		for ready, ok := pending[next]; ok; ready, ok = pending[next] {
+			delete(pending, next)
+			next++
+
+			canvas := p.stream.stack(ready.rendered)
+			if p.failed() == nil {
+				if err := p.emit(ready.line, canvas); err != nil {
+					p.mu.Lock()
+					p.err = err
+					p.mu.Unlock()
+				}
+			}
+			<-p.slots
+		}
+	}
+}
+
+// failed returns the first error from emit, if any
+func (p *ParallelStream) failed() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// Add queues the next line of input, without its newline, blocking while the pool is full
+// Returns the first error from emit so callers can stop reading early
+func (p *ParallelStream) Add(line string) error {
+	if err := p.failed(); err != nil {
+		return err
+	}
+
+	p.slots <- struct{}{}
+	p.jobs <- parallelLine{seq: p.seq, line: line, offset: p.offset}
+	p.seq++
+	p.offset += len(line) + 1
+	return nil
+}
+
+// Held returns the lowest input offset a cell still held back can come from, see Stream.Held
+// It may only be called from emit
+func (p *ParallelStream) Held() int {
+	return p.stream.Held()
+}
+
+// Close waits until every line added has been emitted, stops the workers
+// and returns the rows still held back, like Stream.Flush
+func (p *ParallelStream) Close() (*Canvas, error) {
+	close(p.jobs)
+	<-p.done
+
+	if err := p.failed(); err != nil {
+		return nil, err
+	}
+	return p.stream.Flush(), nil
+}
+
+// renderParallel renders input like Render, spreading its lines over workers goroutines
+func renderParallel(input string, font *Font, opts Options, workers int) *Canvas {
+	canvas := &Canvas{}
+	p := NewParallelStream(font, opts, workers, func(_ string, rows *Canvas) error {
+		canvas.Rows = append(canvas.Rows, rows.Rows...)
+		return nil
+	})
+
+	for _, line := range strings.Split(input, "\n") {
+		p.Add(line)
+	}
+	rest, _ := p.Close()
+	canvas.Rows = append(canvas.Rows, rest.Rows...)
+
+	return canvas
+}
@@ -0,0 +1,126 @@
+package ascii
+
+import (
+	"ascii-art/banners"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BannerPathEnv is the environment variable holding extra banner directories, separated like PATH
+const BannerPathEnv = "ASCII_ART_BANNER_PATH"
+
+// BuiltinSource names the banners built into the binary in listings
+const BuiltinSource = "built-in"
+
+// Registry is the banner search path; the first source holding a banner wins
+type Registry struct {
+	Sources []FontSource
+}
+
+// Banners is the registry used to find, validate, list and load banners by name
+// cmd replaces it once the --banner-dir flag has been parsed
+var Banners = NewRegistry("")
+
+// NewRegistry builds the banner search path in lookup order:
+// bannerDir (the --banner-dir flag) when set, each directory in ASCII_ART_BANNER_PATH,
+// the banners directory in the working directory, $XDG_CONFIG_HOME/ascii-art/banners
+// ($HOME/.config/ascii-art/banners when unset) and finally the banners built into the binary.
+// ./banners comes before the config directory, so a project's banners override the user's.
+func NewRegistry(bannerDir string) *Registry {
+	var dirs []string
+	if bannerDir != "" {
+		dirs = append(dirs, bannerDir)
+	}
+	for _, dir := range filepath.SplitList(os.Getenv(BannerPathEnv)) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	dirs = append(dirs, BannerDir)
+	if configDir := configBannerDir(); configDir != "" {
+		dirs = append(dirs, configDir)
+	}
+
+	registry := &Registry{}
+	for _, dir := range dirs {
+		registry.Sources = append(registry.Sources, FontSource{Name: dir, FS: os.DirFS(dir)})
+	}
+	registry.Sources = append(registry.Sources, FontSource{Name: BuiltinSource, FS: banners.FS})
+
+	return registry
+}
+
+// configBannerDir returns the banner directory in the user's config, "" when there is no home directory
+// XDG_CONFIG_HOME is read on every platform, falling back to $HOME/.config, so the directory is the same on Linux and macOS.
+// A relative XDG_CONFIG_HOME is ignored, as the XDG spec asks.
+func configBannerDir() string {
+	config := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(config) {
+		home := os.Getenv("HOME")
+		if home == "" {
+			return ""
+		}
+		config = filepath.Join(home, ".config")
+	}
+	return filepath.Join(config, "ascii-art", "banners")
+}
+
+// Find returns the first source holding the banner called name and its file there
+func (r *Registry) Find(name string) (FontSource, string, bool) {
+	for _, source := range r.Sources {
+		if file, ok := source.Find(name); ok {
+			return source, file, true
+		}
+	}
+	return FontSource{}, "", false
+}
+
+// Has reports whether any source holds the banner called name
+func (r *Registry) Has(name string) bool {
+	_, _, ok := r.Find(name)
+	return ok
+}
+
+// Load loads the banner called name from the first source that holds it
+func (r *Registry) Load(name string) (*Font, error) {
+	if err := CheckBannerName(name); err != nil {
+		return nil, err
+	}
+
+	source, file, ok := r.Find(name)
+	if !ok {
+		return nil, fmt.Errorf("banner %s not found\nvalid banners: %s", name, strings.Join(r.Names(), ", "))
+	}
+
+	return source.Load(name, file)
+}
+
+// Names lists every banner on the search path once, in alphabetical order
+func (r *Registry) Names() []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, source := range r.Sources {
+		for _, name := range source.Names() {
+			if !seen[name] && CheckBannerName(name) == nil {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// CheckBannerName rejects banner names that could reach outside a banner directory
+// Names must not be empty, contain path separators or start with a dot
+func CheckBannerName(name string) error {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, "/\\\x00") {
+		return fmt.Errorf("invalid banner name %q: names cannot contain path separators or start with a dot", name)
+	}
+	return nil
+}
@@ -1,94 +1,302 @@
 package ascii
 
 import (
-	"fmt"
+	"bufio"
+	"io"
+	"os"
+	"slices"
 	"strings"
 )
 
-func RenderAscii(input string, banner map[rune][]string) {
-	lines := strings.Split(input, "\n")
+// Render lays out input with font and returns the grid of rendered cells
+// Each line of input produces font.Height rows; an empty line produces one empty row.
+// Lines wider than opts.Width are wrapped onto several lines of art first.
+// With vertical fitting or smushing, consecutive lines of text overlap and take fewer rows.
+// With opts.Workers above one, lines are rendered in parallel; the result is the same.
+func Render(input string, font *Font, opts Options) *Canvas {
+	// Only documents with several lines have work to share
+	if opts.Workers > 1 && strings.Contains(input, "\n") {
+		return renderParallel(input, font, opts, opts.Workers)
+	}
 
-	for _, line := range lines {
-		// Build and print each of the 8 ASCII lines for this line of input
-		for row := 0; row < 8; row++ {
-			var outputLine string
-
-			for _, ch := range line {
-				if asciiChar, ok := banner[ch]; ok {
-					outputLine += asciiChar[row]
-				} else {
-					outputLine += "        " // 8 spaces as placeholder
+	stream := NewStream(font, opts)
+	canvas := &Canvas{}
+
+	for _, line := range strings.Split(input, "\n") {
+		canvas.Rows = append(canvas.Rows, stream.Add(line).Rows...)
+	}
+	canvas.Rows = append(canvas.Rows, stream.Flush().Rows...)
+
+	return canvas
+}
+
+// RenderPlain renders input like Render and returns the lines of art, for output without color
+// When glyphs only sit side by side and lines one under another, no cell remembers its source,
+// so each row is written straight from the glyph table into a string of its own.
+func RenderPlain(input string, font *Font, opts Options) []string {
+	if !opts.plainLayout(font) {
+		return Render(input, font, opts).Lines()
+	}
+
+	s := smusher{mode: LayoutFull, hardblank: font.Hardblank}
+	sx, sy := opts.Scale()
+	var lines []string
+	offset := 0
+	for _, line := range strings.Split(input, "\n") {
+		for _, seg := range wrapLine(line, offset, font, opts, s) {
+			lines = seg.appendPlain(lines, font, opts.Fallback, sx, sy)
+		}
+		offset += len(line) + 1
+	}
+	return lines
+}
+
+// plainLayout reports whether opts lay glyphs edge to edge and lines straight below each other, with nothing that needs cell sources
+func (o Options) plainLayout(font *Font) bool {
+	mode, _ := o.horizontalLayout(font)
+	vmode, _ := o.verticalLayout(font)
+	return mode == LayoutFull && vmode == LayoutFull && o.LetterSpacing == 0 && o.LineSpacing == 0 &&
+		o.Direction != DirectionVertical && o.Fill.IsZero() && !o.WholeBlock()
+}
+
+// appendPlain appends the rows the segment renders as to lines, scaled by sx and sy
+// Like render, an empty segment is one empty row.
+func (seg textSegment) appendPlain(lines []string, font *Font, fallback Fallback, sx, sy int) []string {
+	if seg.text == "" {
+		return appendRepeated(lines, "", sy)
+	}
+
+	text := seg.text
+	if seg.hyphen {
+		text += "-"
+	}
+	glyphs := make([]*tableGlyph, 0, len(text))
+	width := 0
+	for _, ch := range text {
+		glyph, ok := fallback.resolve(font, ch)
+		if !ok {
+			continue // skipped by the fallback policy
+		}
+		glyphs = append(glyphs, glyph.tableGlyph)
+		width += glyph.width
+	}
+	if font.RightToLeft() {
+		slices.Reverse(glyphs)
+	}
+
+	var sb strings.Builder
+	for row := 0; row < font.Height; row++ {
+		sb.Grow(width * sx)
+		for _, glyph := range glyphs {
+			glyph.writeRow(&sb, row, font.Hardblank, sx)
+		}
+		lines = appendRepeated(lines, sb.String(), sy)
+		sb.Reset()
+	}
+	return lines
+}
+
+// appendRepeated appends n copies of line to lines
+func appendRepeated(lines []string, line string, n int) []string {
+	for range n {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// renderLine lays out a single line of text that starts at byte offset in the input
+// Every character is resolved once, and the rows are sized up front for the widest layout.
+// With a right-to-left font the glyphs are laid out from the last character to the first.
+func renderLine(line string, offset int, font *Font, fallback Fallback, s smusher) [][]Cell {
+	glyphs := make([]placedGlyph, 0, len(line))
+	total := 0
+	for i, ch := range line {
+		glyph, ok := fallback.place(font, ch, offset+i)
+		if !ok {
+			continue // skipped by the fallback policy
+		}
+		glyphs = append(glyphs, glyph)
+		total += glyph.width
+	}
+	if s.spacing > 0 && len(glyphs) > 1 {
+		total += s.spacing * (len(glyphs) - 1)
+	}
+	if font.RightToLeft() {
+		slices.Reverse(glyphs)
+	}
+
+	layout := newGlyphLayout(font.Height, total, s)
+	for _, glyph := range glyphs {
+		layout.add(glyph)
+	}
+	rows := layout.rows
+
+	// Hardblanks only matter while smushing, they are drawn as spaces
+	if font.Hardblank != 0 {
+		for _, row := range rows {
+			for i := range row {
+				if row[i].Ch == font.Hardblank {
+					row[i].Ch = ' '
 				}
 			}
+		}
+	}
+
+	return rows
+}
+
+// glyphLayout lays glyphs out one after another with the layout mode, smushing rules and spacing of a smusher
+// A glyph never makes the rows narrower, so the width after each glyph is the width of the text so far.
+type glyphLayout struct {
+	s         smusher
+	rows      [][]Cell // Rows laid out so far, all the same length
+	prevWidth int      // Width of the last glyph placed, 0 before the first
+	grid      [][]Cell // Rows of the glyph being placed
+	buf       []Cell   // Cells of grid, reused from glyph to glyph
+}
 
-			fmt.Println(outputLine)
+// newGlyphLayout creates an empty layout of height rows with room for capacity columns
+// One backing array holds every row; overlapping only ever makes rows shorter than the sum of the glyphs.
+func newGlyphLayout(height, capacity int, s smusher) *glyphLayout {
+	rows := make([][]Cell, height)
+	cells := make([]Cell, height*capacity)
+	for row := range rows {
+		rows[row] = cells[row*capacity : row*capacity : (row+1)*capacity]
+	}
+	return &glyphLayout{s: s, rows: rows, grid: make([][]Cell, height)}
+}
+
+// width returns the number of columns laid out so far
+func (l *glyphLayout) width() int {
+	if len(l.rows) == 0 {
+		return 0
+	}
+	return len(l.rows[0])
+}
+
+// add places glyph after the glyphs already laid out
+func (l *glyphLayout) add(glyph placedGlyph) {
+	if l.s.mode == LayoutFull && l.s.spacing == 0 {
+		// Glyphs are placed edge to edge, straight from the glyph table
+		for row := range l.rows {
+			l.rows[row] = glyph.appendRow(l.rows[row], row)
+		}
+		l.prevWidth = glyph.width
+		return
+	}
+
+	l.buf = glyph.cellsInto(l.grid, l.buf)
+	amount := l.overlap(l.grid)
+	if amount < 0 {
+		l.rows = padColumns(l.rows, -amount)
+		amount = 0
+	}
+	l.rows = l.s.place(l.rows, l.grid, amount, l.prevWidth)
+	l.prevWidth = glyph.width
+}
+
+// widthWith returns the width the layout would have with glyph added, without adding it
+func (l *glyphLayout) widthWith(glyph placedGlyph) int {
+	grid := make([][]Cell, len(l.rows))
+	glyph.cellsInto(grid, nil)
+	return l.width() + glyph.width - l.overlap(grid)
+}
+
+// overlap returns how many columns the glyph laid out in grid slides into the rows, negative for a gap
+func (l *glyphLayout) overlap(grid [][]Cell) int {
+	if l.prevWidth == 0 {
+		return 0
+	}
+
+	amount := l.s.overlap(l.rows, grid, l.prevWidth)
+	if l.s.spacing != 0 {
+		amount = spacedOverlap(amount, l.s.spacing, func() int {
+			return smusher{mode: LayoutFit, hardblank: l.s.hardblank}.overlap(l.rows, grid, l.prevWidth)
+		})
+	}
+	return amount
+}
+
+// placedGlyph is a resolved glyph together with the input offset its cells come from
+type placedGlyph struct {
+	*tableGlyph
+	src int // Byte offset of the character in the input, -1 for placeholders
+}
+
+// place resolves ch, found at byte offset src of the input, to the glyph drawn for it
+// Returns false when the fallback policy skips the character
+func (f Fallback) place(font *Font, ch rune, src int) (placedGlyph, bool) {
+	glyph, ok := f.resolve(font, ch)
+	if !ok {
+		return placedGlyph{}, false
+	}
+	if !glyph.ink {
+		src = -1 // placeholder is not part of the character art
+	}
+	return placedGlyph{tableGlyph: glyph.tableGlyph, src: src}, true
+}
+
+// appendRow appends one row of the glyph to dst, as spaces past the end of the glyph
+func (g placedGlyph) appendRow(dst []Cell, row int) []Cell {
+	start := len(dst)
+	dst = slices.Grow(dst, g.width)[:start+g.width]
+	out := dst[start:]
+
+	if row < len(g.rows) {
+		for i, r := range g.rows[row] {
+			out[i] = Cell{Ch: r, Src: g.src}
 		}
+		return dst
 	}
+
+	for i := range out {
+		out[i] = Cell{Ch: ' ', Src: g.src}
+	}
+	return dst
 }
 
-// This version of RenderAscii does handles terminal wrapping more efficiently.
-// func RenderAsciiWithTerminalWrapping(input string, banner map[rune][]string) {
-// 	termWidth, _, err := term.GetSize(int(os.Stdout.Fd()))
-// 	if err != nil {
-// 		termWidth = 80 // Fallback to a default width if detection fails
-// 	}
-
-// 	// Handle the case where the input string is just newlines
-// 	if len(strings.ReplaceAll(input, "\n", "")) == 0 {
-// 		fmt.Print(strings.Repeat("\n", len(strings.Split(input, "\n"))-1))
-// 	}
-
-// 	// Split the user's input by the "\n" delimiter
-// 	inputFields := strings.Split(input, "\n")
-
-// 	for _, line := range inputFields {
-// 		if line == "" {
-// 			fmt.Println()
-// 			continue
-// 		}
-
-// 		// outputRows will store the 8 lines of the final, wrapped output
-// 		var outputRows [8]string
-
-// 		for _, ch := range line {
-// 			// Look up the character art from the map
-// 			charArt, ok := banner[ch]
-
-// 			// If character is not in the banner map, we can't process it.
-// 			// We'll skip it to avoid breaking the alignment.
-// 			if !ok {
-// 				// You could also print a placeholder or log a warning here.
-// 				continue
-// 			}
-
-// 			// Get the width of this specific character's art
-// 			charWidth := 0
-// 			if len(charArt) > 0 {
-// 				charWidth = len(charArt[0])
-// 			}
-
-// 			// DYNAMIC WRAPPING LOGIC:
-// 			// Before adding the new character, check if it will exceed the terminal width.
-// 			if len(outputRows[0])+charWidth > termWidth {
-// 				// If it exceeds, print the lines we've built so far...
-// 				for _, row := range outputRows {
-// 					fmt.Println(row)
-// 				}
-// 				// ...and reset for the next line of wrapped output.
-// 				outputRows = [8]string{}
-// 			}
-
-// 			// Append the new character's art to our output rows
-// 			for i := 0; i < 8; i++ {
-// 				if i < len(charArt) {
-// 					outputRows[i] += charArt[i]
-// 				}
-// 			}
-// 		}
-
-// 		// After processing a line, print any remaining content in outputRows
-// 		for _, row := range outputRows {
-// 			fmt.Println(row)
-// 		}
-// 	}
-// }
+// cellsInto lays the glyph out in grid, cut or padded to len(grid) rows, reusing buf for the cells
+// Returns buf so the next glyph can reuse it; place copies cells, so nothing keeps the grid.
+func (g placedGlyph) cellsInto(grid [][]Cell, buf []Cell) []Cell {
+	buf = slices.Grow(buf[:0], len(grid)*g.width)
+	for row := range grid {
+		start := len(buf)
+		buf = g.appendRow(buf, row)
+		grid[row] = buf[start:len(buf):len(buf)]
+	}
+	return buf
+}
+
+// RenderLines builds the ASCII art rows for input without printing them
+// The font's own layout is used; see RenderPlain for other options
+func RenderLines(input string, font *Font) []string {
+	return RenderPlain(input, font, Options{})
+}
+
+// RenderString returns the rendered ASCII art as a single newline-terminated string
+func RenderString(input string, font *Font) string {
+	var sb strings.Builder
+	WriteLines(&sb, RenderLines(input, font))
+	return sb.String()
+}
+
+// RenderTo writes the rendered ASCII art to w
+func RenderTo(w io.Writer, input string, font *Font) error {
+	return WriteLines(w, RenderLines(input, font))
+}
+
+// RenderAscii prints the rendered ASCII art to stdout
+func RenderAscii(input string, font *Font) {
+	RenderTo(os.Stdout, input, font)
+}
+
+// WriteLines writes each line to w followed by a newline
+// Lines are buffered so that long outputs take few writes
+func WriteLines(w io.Writer, lines []string) error {
+	bw := bufio.NewWriter(w)
+	for _, line := range lines {
+		bw.WriteString(line)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,26 @@
+package ascii
+
+// scaleRows magnifies rows, repeating every cell sx times across and every row sy times down
+// Copies of a cell share its source, so measuring sees the scaled art as one character.
+func scaleRows(rows [][]Cell, sx, sy int) [][]Cell {
+	if sx == 1 && sy == 1 {
+		return rows
+	}
+
+	scaled := make([][]Cell, 0, len(rows)*sy)
+	for _, row := range rows {
+		wide := make([]Cell, 0, len(row)*sx)
+		for _, cell := range row {
+			for i := 0; i < sx; i++ {
+				wide = append(wide, cell)
+			}
+		}
+
+		// Every copy of the row is its own slice, so later stages may change one safely
+		scaled = append(scaled, wide)
+		for i := 1; i < sy; i++ {
+			scaled = append(scaled, append([]Cell(nil), wide...))
+		}
+	}
+	return scaled
+}
@@ -0,0 +1,95 @@
+package ascii
+
+import "math"
+
+// SrcShadow is the source of the cells of a shadow, so they can be colored apart from the text
+const SrcShadow = -3
+
+// Shadow is the ink of the art drawn again behind it, offset by DX columns and DY rows
+// The zero value draws no shadow.
+type Shadow struct {
+	DX, DY  int  // Offset of the shadow, negative to cast it left or up
+	Char    rune // Character the shadow is drawn with, 0 to repeat the characters of the art
+	Extrude bool // Draw the shadow at every step up to the offset, for a solid 3D extrusion
+}
+
+// IsZero reports whether s draws nothing
+func (s Shadow) IsZero() bool {
+	return s.DX == 0 && s.DY == 0
+}
+
+// Size returns the columns and rows s adds to the art
+func (s Shadow) Size() (int, int) {
+	return abs(s.DX), abs(s.DY)
+}
+
+// steps returns the offsets s is drawn at, farthest first, so nearer steps are drawn over farther ones
+func (s Shadow) steps() [][2]int {
+	if !s.Extrude {
+		return [][2]int{{s.DX, s.DY}}
+	}
+
+	// Steps follow the straight line to the offset, one column or row at a time
+	n := max(abs(s.DX), abs(s.DY))
+	steps := make([][2]int, 0, n)
+	for k := n; k >= 1; k-- {
+		dx := int(math.Round(float64(s.DX*k) / float64(n)))
+		dy := int(math.Round(float64(s.DY*k) / float64(n)))
+		steps = append(steps, [2]int{dx, dy})
+	}
+	return steps
+}
+
+// Apply returns the canvas with s drawn behind its ink
+// Ink is every cell that isn't blank. The canvas grows by the offset, on the left or top for a
+// negative one, and the art's own blank cells let the shadow show through.
+// Shadow cells have SrcShadow.
+func (s Shadow) Apply(c *Canvas) *Canvas {
+	if s.IsZero() || c.Height() == 0 {
+		return c
+	}
+
+	columns, rows := s.Size()
+	left, top := max(-s.DX, 0), max(-s.DY, 0)
+	grid := blankCells(c.Height()+rows, c.Width()+columns)
+
+	for _, step := range s.steps() {
+		for r, row := range c.Rows {
+			for col, cell := range row {
+				if cell.Ch == ' ' {
+					continue
+				}
+				ch := s.Char
+				if ch == 0 {
+					ch = cell.Ch
+				}
+				grid[top+r+step[1]][left+col+step[0]] = Cell{Ch: ch, Src: SrcShadow}
+			}
+		}
+	}
+
+	for r, row := range c.Rows {
+		for col, cell := range row {
+			if cell.Ch != ' ' {
+				grid[top+r][left+col] = cell
+			}
+		}
+	}
+	return &Canvas{Rows: grid}
+}
+
+// ShadowLines returns lines with s drawn behind them, like Shadow.Apply
+func ShadowLines(lines []string, s Shadow) []string {
+	if s.IsZero() {
+		return lines
+	}
+	return s.Apply(linesCanvas(lines)).Lines()
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
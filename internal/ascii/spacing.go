@@ -0,0 +1,21 @@
+package ascii
+
+// spacedOverlap adjusts the overlap a layout chose between two glyphs or lines of text by spacing
+// Positive spacing moves the next one further away, past zero into a blank gap.
+// Negative spacing slides it closer, but never further than it fits without touching.
+func spacedOverlap(amount, spacing int, fit func() int) int {
+	if spacing >= 0 {
+		return amount - spacing
+	}
+	return max(amount, min(amount-spacing, fit()))
+}
+
+// padColumns appends width blank columns that come from no input to every row
+func padColumns(rows [][]Cell, width int) [][]Cell {
+	for row := range rows {
+		for i := 0; i < width; i++ {
+			rows[row] = append(rows[row], Cell{Ch: ' ', Src: -1})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,129 @@
+package ascii
+
+// Stream renders input one line at a time as it arrives, so large inputs never need to be buffered
+// With vertical fitting or smushing the rows of the last line of text can still be overlapped
+// by the next one, so they are held back until the next Add or Flush.
+type Stream struct {
+	font *Font
+	opts Options
+	s    smusher
+	vs   vsmusher
+
+	rows       [][]Cell // Rows held back for the next line of text
+	prevHeight int      // Rows at the end of rows that belong to the last line of text
+	offset     int      // Byte offset of the next line in the whole input
+}
+
+// NewStream creates a Stream that renders with font and opts
+func NewStream(font *Font, opts Options) *Stream {
+	mode, rules := opts.horizontalLayout(font)
+	vmode, vrules := opts.verticalLayout(font)
+
+	// Width counts the columns of the finished block
+	opts.Width = opts.InnerWidth(opts.Width)
+
+	return &Stream{
+		font: font,
+		opts: opts,
+		s:    smusher{mode: mode, rules: rules, hardblank: font.Hardblank, spacing: opts.LetterSpacing},
+		vs:   vsmusher{mode: vmode, rules: vrules, spacing: opts.LineSpacing},
+	}
+}
+
+// Add renders the next line of input, without its newline, and returns the rows that are final
+// Cell sources are byte offsets in the whole input, counting one byte for each newline
+func (st *Stream) Add(line string) *Canvas {
+	return st.stack(st.render(line, st.offset))
+}
+
+// render lays out the wrapped segments of a line of input that starts at offset
+// It only reads the stream's settings, so lines can be rendered on other goroutines
+func (st *Stream) render(line string, offset int) renderedLine {
+	// A vertical line of text is one column and is never wrapped; letter spacing is between its glyphs
+	if st.opts.Direction == DirectionVertical {
+		vs := st.vs
+		vs.spacing = st.opts.LetterSpacing
+		column := renderColumn(line, offset, st.font, st.opts.Fallback, vs)
+		st.opts.Fill.apply([][][]Cell{column}, line, offset)
+		return renderedLine{blocks: [][][]Cell{column}, size: len(line)}
+	}
+
+	segments := wrapLine(line, offset, st.font, st.opts, st.s)
+	blocks := make([][][]Cell, len(segments))
+	for i, seg := range segments {
+		blocks[i] = [][]Cell{{}}
+		if seg.text != "" {
+			blocks[i] = seg.render(st.font, st.opts.Fallback, st.s)
+		}
+	}
+	st.opts.Fill.apply(blocks, line, offset)
+	return renderedLine{blocks: blocks, size: len(line)}
+}
+
+// stack adds a rendered line below the rows held back and returns the rows that are final
+func (st *Stream) stack(rendered renderedLine) *Canvas {
+	// Vertical text grows to the right, so every row can still change until Flush
+	if st.opts.Direction == DirectionVertical {
+		st.rows = besideColumn(st.rows, rendered.blocks[0], st.opts.LineSpacing)
+		st.offset += rendered.size + 1
+		return &Canvas{}
+	}
+
+	for i, block := range rendered.blocks {
+		// Every line of text but the very first is spaced from the one above, even once that was written
+		st.rows = st.vs.stack(st.rows, block, st.prevHeight, st.offset > 0 || i > 0)
+		st.prevHeight = len(block)
+	}
+	st.offset += rendered.size + 1
+
+	// A transform, effect, inversion, shadow or border needs the whole block, so nothing is final until Flush
+	if st.opts.WholeBlock() {
+		return &Canvas{}
+	}
+
+	// Only rows the next line could slide into are kept
+	keep := 0
+	if st.vs.mode != LayoutFull {
+		keep = st.prevHeight
+	}
+
+	done := st.scaled(st.rows[:len(st.rows)-keep])
+	st.rows = append([][]Cell{}, st.rows[len(st.rows)-keep:]...)
+	st.prevHeight = keep
+	return done
+}
+
+// renderedLine is a line of input laid out but not yet stacked
+type renderedLine struct {
+	blocks [][][]Cell // One block of rows per wrapped segment
+	size   int        // Length of the line in bytes
+}
+
+// Flush returns the rows still held back, finished with the transform, effect, inversion, shadow and border the options ask for; the stream is then done
+func (st *Stream) Flush() *Canvas {
+	done := st.opts.Finish(st.scaled(st.rows))
+	st.rows = nil
+	st.prevHeight = 0
+	return done
+}
+
+// scaled returns finished rows as a canvas, magnified by the scale factors in the options
+// Layout and vertical overlap work on unscaled rows, so scaled art is exactly the art made bigger.
+func (st *Stream) scaled(rows [][]Cell) *Canvas {
+	sx, sy := st.opts.Scale()
+	return &Canvas{Rows: scaleRows(rows, sx, sy)}
+}
+
+// Held returns the lowest input offset a cell still held back can come from
+// Rows returned later never draw characters before it
+func (st *Stream) Held() int {
+	held := st.offset
+	for _, row := range st.rows {
+		for _, cell := range row {
+			if cell.Src >= 0 && cell.Src < held {
+				held = cell.Src
+			}
+		}
+	}
+	return held
+}
@@ -0,0 +1,145 @@
+package ascii
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultTabWidth is the distance between tab stops, in characters, when TextOptions does not set one
+const DefaultTabWidth = 4
+
+// ControlMode selects what happens to control characters other than newline and tab
+type ControlMode int
+
+const (
+	ControlStrip ControlMode = iota // Remove them from the text
+	ControlError                    // Refuse the text, see NormalizeText
+)
+
+// ControlModeNames maps the keywords accepted by --control to modes
+var ControlModeNames = map[string]ControlMode{
+	"strip": ControlStrip,
+	"error": ControlError,
+}
+
+// TextOptions controls how input text is normalised before rendering
+// The zero value decodes escapes, uses DefaultTabWidth and strips control characters
+type TextOptions struct {
+	Raw      bool        // Leave backslash escapes as they are
+	TabWidth int         // Characters between tab stops, DefaultTabWidth when zero
+	Control  ControlMode // What to do with other control characters
+}
+
+// Normalization is the text normalisation applied to command line text, set by main from its flags
+var Normalization TextOptions
+
+// NormalizeText decodes escape sequences, expands tabs to tab stops and removes control characters
+// Recognised escapes are \n, \t, \\, \xNN and \u{...}; other backslashes are kept as typed.
+func NormalizeText(text string, opts TextOptions) (string, error) {
+	if !opts.Raw {
+		decoded, err := decodeEscapes(text)
+		if err != nil {
+			return "", err
+		}
+		text = decoded
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		normalized, err := normalizeLine(line, opts)
+		if err != nil {
+			return "", err
+		}
+		lines[i] = normalized
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// tabWidth returns the distance between tab stops
+func (o TextOptions) tabWidth() int {
+	if o.TabWidth <= 0 {
+		return DefaultTabWidth
+	}
+	return o.TabWidth
+}
+
+// normalizeLine expands the tabs of a single line and handles its control characters
+func normalizeLine(line string, opts TextOptions) (string, error) {
+	var sb strings.Builder
+	column := 0
+
+	for _, ch := range line {
+		switch {
+		case ch == '\t':
+			// Pad to the next tab stop
+			spaces := opts.tabWidth() - column%opts.tabWidth()
+			sb.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+		case unicode.IsControl(ch):
+			if opts.Control == ControlError {
+				return "", WrapControlCharError(ch)
+			}
+		default:
+			sb.WriteRune(ch)
+			column++
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// decodeEscapes replaces the backslash escape sequences in text with the characters they stand for
+func decodeEscapes(text string) (string, error) {
+	var sb strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' || i+1 == len(text) {
+			sb.WriteByte(text[i])
+			continue
+		}
+
+		switch text[i+1] {
+		case 'n':
+			sb.WriteByte('\n')
+			i++
+		case 't':
+			sb.WriteByte('\t')
+			i++
+		case '\\':
+			sb.WriteByte('\\')
+			i++
+		case 'x':
+			// Exactly two hex digits
+			if i+4 > len(text) {
+				return "", WrapEscapeError(text[i:])
+			}
+			value, err := strconv.ParseUint(text[i+2:i+4], 16, 8)
+			if err != nil {
+				return "", WrapEscapeError(text[i : i+4])
+			}
+			sb.WriteRune(rune(value))
+			i += 3
+		case 'u':
+			// One to six hex digits in braces
+			end := strings.IndexByte(text[i:], '}')
+			if i+2 >= len(text) || text[i+2] != '{' || end < 0 {
+				return "", WrapEscapeError(text[i:min(i+2, len(text))])
+			}
+			seq := text[i : i+end+1]
+			digits := seq[3 : len(seq)-1]
+			value, err := strconv.ParseUint(digits, 16, 32)
+			if err != nil || len(digits) > 6 || !utf8.ValidRune(rune(value)) {
+				return "", WrapEscapeError(seq)
+			}
+			sb.WriteRune(rune(value))
+			i += len(seq) - 1
+		default:
+			// Unknown escapes are kept as typed
+			sb.WriteByte('\\')
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,106 @@
+package ascii
+
+// Transform is a geometric transform applied to the whole rendered block
+type Transform int
+
+const (
+	TransformNone      Transform = iota // Leave the art as rendered
+	TransformMirror                     // Reflect left to right
+	TransformFlip                       // Reflect top to bottom
+	TransformRotate90                   // Turn a quarter clockwise
+	TransformRotate180                  // Turn upside down
+	TransformRotate270                  // Turn a quarter anticlockwise
+)
+
+// TransformNames maps the names accepted by --transform to transforms
+var TransformNames = map[string]Transform{
+	"mirror":    TransformMirror,
+	"flip":      TransformFlip,
+	"rotate90":  TransformRotate90,
+	"rotate180": TransformRotate180,
+	"rotate270": TransformRotate270,
+}
+
+// Character swaps that keep direction-sensitive strokes pointing the right way after a transform
+var (
+	mirrorRunes = map[rune]rune{'/': '\\', '\\': '/', '(': ')', ')': '(', '<': '>', '>': '<', '[': ']', ']': '[', '{': '}', '}': '{'}
+	flipRunes   = map[rune]rune{'/': '\\', '\\': '/'}
+	rotateRunes = map[rune]rune{'|': '-', '-': '|', '/': '\\', '\\': '/'}
+
+	// Mirroring and flipping both swap slashes, so turning upside down leaves them alone
+	rotate180Runes = map[rune]rune{'(': ')', ')': '(', '<': '>', '>': '<', '[': ']', ']': '[', '{': '}', '}': '{'}
+)
+
+// Apply returns the canvas transformed by t
+// Rows are padded to the canvas width first, so the block keeps its shape; padding has no source.
+func (t Transform) Apply(c *Canvas) *Canvas {
+	if t == TransformNone {
+		return c
+	}
+
+	width, height := c.Width(), c.Height()
+	at := func(row, col int) Cell {
+		if col < len(c.Rows[row]) {
+			return c.Rows[row][col]
+		}
+		return Cell{Ch: ' ', Src: -1}
+	}
+
+	// Transformed grid dimensions and where each new cell comes from
+	outWidth, outHeight := width, height
+	var source func(row, col int) Cell
+	var swaps map[rune]rune
+
+	switch t {
+	case TransformMirror:
+		source = func(row, col int) Cell { return at(row, width-1-col) }
+		swaps = mirrorRunes
+	case TransformFlip:
+		source = func(row, col int) Cell { return at(height-1-row, col) }
+		swaps = flipRunes
+	case TransformRotate180:
+		source = func(row, col int) Cell { return at(height-1-row, width-1-col) }
+		swaps = rotate180Runes
+	case TransformRotate90:
+		outWidth, outHeight = height, width
+		source = func(row, col int) Cell { return at(height-1-col, row) }
+		swaps = rotateRunes
+	case TransformRotate270:
+		outWidth, outHeight = height, width
+		source = func(row, col int) Cell { return at(col, width-1-row) }
+		swaps = rotateRunes
+	}
+
+	rows := make([][]Cell, outHeight)
+	for row := range rows {
+		rows[row] = make([]Cell, outWidth)
+		for col := range rows[row] {
+			cell := source(row, col)
+			if swapped, ok := swaps[cell.Ch]; ok {
+				cell.Ch = swapped
+			}
+			rows[row][col] = cell
+		}
+	}
+	return &Canvas{Rows: rows}
+}
+
+// TransformLines applies t to already rendered lines, such as justified text
+func TransformLines(lines []string, t Transform) []string {
+	if t == TransformNone {
+		return lines
+	}
+
+	return t.Apply(linesCanvas(lines)).Lines()
+}
+
+// linesCanvas returns already rendered lines as a canvas whose cells have no source
+func linesCanvas(lines []string) *Canvas {
+	canvas := &Canvas{Rows: make([][]Cell, len(lines))}
+	for i, line := range lines {
+		for _, r := range line {
+			canvas.Rows[i] = append(canvas.Rows[i], Cell{Ch: r, Src: -1})
+		}
+	}
+	return canvas
+}
@@ -0,0 +1,273 @@
+package ascii
+
+import (
+	"strings"
+)
+
+// VerticalLayout returns the vertical layout mode and smushing rules stored in the font header
+func (f *Font) VerticalLayout() (Layout, int) {
+	rules := f.FullLayout & VSmushAllRules
+
+	switch {
+	case f.FullLayout&LayoutVSmushBit != 0:
+		return LayoutSmush, rules
+	case f.FullLayout&LayoutVFitBit != 0:
+		return LayoutFit, rules
+	default:
+		return LayoutFull, rules
+	}
+}
+
+// SetVerticalLayout stores a vertical layout mode in the font header, keeping its smushing rules
+func (f *Font) SetVerticalLayout(mode Layout) {
+	f.FullLayout &^= LayoutVFitBit | LayoutVSmushBit
+
+	switch mode {
+	case LayoutFit:
+		f.FullLayout |= LayoutVFitBit
+	case LayoutSmush:
+		f.FullLayout |= LayoutVSmushBit
+	}
+}
+
+// verticalLayout resolves the vertical layout mode and smushing rules used to render with font
+// Smushing requested on a font without vertical rules uses all five rules
+func (o Options) verticalLayout(font *Font) (Layout, int) {
+	mode, rules := font.VerticalLayout()
+
+	switch o.VLayout {
+	case LayoutDefault:
+		return mode, rules
+	case LayoutSmush:
+		if rules == 0 {
+			rules = VSmushAllRules
+		}
+		return LayoutSmush, rules
+	default:
+		return o.VLayout, rules
+	}
+}
+
+// vsmusher merges the rows of consecutive lines of text according to a vertical layout mode
+type vsmusher struct {
+	mode    Layout
+	rules   int
+	spacing int // Blank rows added between lines of text, see spacedOverlap
+}
+
+// smush returns the character produced by overlapping upper and lower, or 0 if they cannot merge
+func (s vsmusher) smush(upper, lower rune) rune {
+	if upper == ' ' {
+		return lower
+	}
+	if lower == ' ' {
+		return upper
+	}
+
+	if s.mode != LayoutSmush {
+		return 0
+	}
+
+	// Universal smushing: the lower character wins
+	if s.rules == 0 {
+		return lower
+	}
+
+	if s.rules&VSmushEqual != 0 && upper == lower {
+		return upper
+	}
+
+	if s.rules&VSmushUnderscore != 0 {
+		if upper == '_' && strings.ContainsRune(`|/\[]{}()<>`, lower) {
+			return lower
+		}
+		if lower == '_' && strings.ContainsRune(`|/\[]{}()<>`, upper) {
+			return upper
+		}
+	}
+
+	if s.rules&VSmushHierarchy != 0 {
+		if winner := hierarchyWinner(upper, lower); winner != 0 {
+			return winner
+		}
+	}
+
+	if s.rules&VSmushHorizontal != 0 {
+		if (upper == '-' && lower == '_') || (upper == '_' && lower == '-') {
+			return '='
+		}
+	}
+
+	if s.rules&VSmushVertical != 0 && upper == '|' && lower == '|' {
+		return '|'
+	}
+
+	return 0
+}
+
+// stack appends block below rows, moving it up into the previous block as far as the layout allows
+// prevHeight is the number of rows at the end of rows that belong to the previous line of text.
+// spaced is false for the first line of text, which is never moved away from what is above it.
+func (s vsmusher) stack(rows [][]Cell, block [][]Cell, prevHeight int, spaced bool) [][]Cell {
+	prev := rows[len(rows)-prevHeight:]
+	amount := s.overlap(prev, block)
+	if spaced && s.spacing != 0 {
+		amount = spacedOverlap(amount, s.spacing, func() int {
+			return vsmusher{mode: LayoutFit}.overlap(prev, block)
+		})
+	}
+
+	// A gap is as wide as the line below it, and never empty, so it can't be taken for an empty line
+	if amount < 0 {
+		rows = append(rows, blankCells(-amount, max(gridWidth(block), 1))...)
+		amount = 0
+	}
+	return s.place(rows, block, amount)
+}
+
+// overlap returns how many rows block can slide up into prev
+// Lines of text without any ink, such as empty lines, are never overlapped
+func (s vsmusher) overlap(prev [][]Cell, block [][]Cell) int {
+	if s.mode == LayoutFull || !hasInk(prev) || !hasInk(block) {
+		return 0
+	}
+
+	amount := len(prev)
+	if len(block) < amount {
+		amount = len(block)
+	}
+
+	width := gridWidth(prev)
+	if w := gridWidth(block); w > width {
+		width = w
+	}
+
+	for col := 0; col < width; col++ {
+		// Lowest ink in the previous block
+		bottom := len(prev) - 1
+		for bottom >= 0 && cellAt(prev, bottom, col) == ' ' {
+			bottom--
+		}
+
+		// Highest ink in the new block
+		top := 0
+		for top < len(block) && cellAt(block, top, col) == ' ' {
+			top++
+		}
+
+		// Nothing can collide in a column that is blank on either side
+		if bottom < 0 || top == len(block) {
+			continue
+		}
+
+		colAmount := len(prev) - 1 - bottom + top
+		if s.smush(cellAt(prev, bottom, col), cellAt(block, top, col)) != 0 {
+			colAmount++
+		}
+
+		if colAmount < amount {
+			amount = colAmount
+		}
+	}
+
+	return amount
+}
+
+// place appends block to rows, merging its first amount rows into the last amount rows
+func (s vsmusher) place(rows [][]Cell, block [][]Cell, amount int) [][]Cell {
+	start := len(rows) - amount
+
+	for i, chars := range block {
+		target := start + i
+		if target >= len(rows) {
+			// Blocks are freshly rendered, so their rows are taken over without a copy
+			rows = append(rows, chars)
+			continue
+		}
+
+		line := rows[target]
+		for len(line) < len(chars) {
+			line = append(line, Cell{Ch: ' ', Src: -1})
+		}
+
+		for col, lower := range chars {
+			upper := line[col]
+			if lower.Ch == ' ' {
+				continue
+			}
+			if upper.Ch == ' ' {
+				line[col] = lower
+				continue
+			}
+
+			merged := s.smush(upper.Ch, lower.Ch)
+			if merged == 0 || merged == lower.Ch {
+				// Smushing is only attempted where overlap allowed it, so fall back to the lower cell
+				line[col] = lower
+			} else if merged != upper.Ch {
+				line[col] = Cell{Ch: merged, Src: lower.Src}
+			}
+		}
+		rows[target] = line
+	}
+
+	return rows
+}
+
+// StackLines joins already rendered lines of text with the vertical layout from opts
+// Each block holds the rows of one line of text; blocks are already scaled, so line spacing is scaled too
+func StackLines(blocks [][]string, font *Font, opts Options) []string {
+	canvases := make([]*Canvas, len(blocks))
+	for i, block := range blocks {
+		canvases[i] = linesCanvas(block)
+	}
+	return StackBlocks(canvases, font, opts).Lines()
+}
+
+// StackBlocks joins already rendered lines of text with the vertical layout from opts, like StackLines
+// It returns the cells rather than strings, so the stacked block can still be colored.
+func StackBlocks(blocks []*Canvas, font *Font, opts Options) *Canvas {
+	mode, rules := opts.verticalLayout(font)
+	_, sy := opts.Scale()
+	vs := vsmusher{mode: mode, rules: rules, spacing: opts.LineSpacing * sy}
+
+	var rows [][]Cell
+	prevHeight := 0
+	for i, block := range blocks {
+		rows = vs.stack(rows, block.Rows, prevHeight, i > 0)
+		prevHeight = len(block.Rows)
+	}
+
+	return &Canvas{Rows: rows}
+}
+
+// hasInk reports whether any cell in rows is not a space
+func hasInk(rows [][]Cell) bool {
+	for _, row := range rows {
+		for _, cell := range row {
+			if cell.Ch != ' ' {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// gridWidth returns the length of the longest row
+func gridWidth(rows [][]Cell) int {
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	return width
+}
+
+// cellAt returns the character at row, col, treating cells past the end of a row as spaces
+func cellAt(rows [][]Cell, row, col int) rune {
+	if col < len(rows[row]) {
+		return rows[row][col].Ch
+	}
+	return ' '
+}
@@ -0,0 +1,198 @@
+package ascii
+
+import (
+	"unicode/utf8"
+)
+
+// textSegment is a piece of an input line that is rendered as its own line of art
+type textSegment struct {
+	text   string // Text of the segment
+	offset int    // Byte offset of text in the input
+	hyphen bool   // Whether a hyphen is drawn after the text
+}
+
+// render lays out the segment, drawing the hyphen with no source character
+func (seg textSegment) render(font *Font, fallback Fallback, s smusher) [][]Cell {
+	if !seg.hyphen {
+		return renderLine(seg.text, seg.offset, font, fallback, s)
+	}
+
+	rows := renderLine(seg.text+"-", seg.offset, font, fallback, s)
+	hyphenSrc := seg.offset + len(seg.text)
+	for _, row := range rows {
+		for i := range row {
+			if row[i].Src == hyphenSrc {
+				row[i].Src = -1
+			}
+		}
+	}
+	return rows
+}
+
+// WrapWidth returns the width art is wrapped to, 0 for none
+// An explicit width always wins. Without one, art printed to a terminal wraps to terminalWidth(),
+// while art written to a file or a pipe is left as wide as it renders.
+func WrapWidth(width int, terminal bool, terminalWidth func() int) int {
+	if width == 0 && terminal {
+		return terminalWidth()
+	}
+	return width
+}
+
+// wrapLine splits a line of input that starts at byte offset into segments whose art fits opts.Width
+// Lines break at spaces; a word wider than the width is broken between characters,
+// with a hyphen when opts.Hyphenate is set. Every segment holds at least one character.
+// Widths are measured as the glyphs are laid out, so each character is only laid out again when its segment is rendered.
+// Right-to-left text is measured in reading order; it only differs from the drawn width where fitted or smushed glyphs overlap differently.
+func wrapLine(line string, offset int, font *Font, opts Options, s smusher) []textSegment {
+	whole := textSegment{text: line, offset: offset}
+	if opts.Width <= 0 {
+		return []textSegment{whole}
+	}
+
+	// Width counts columns of the scaled art
+	sx, _ := opts.Scale()
+	fits := func(width int) bool {
+		return width*sx <= opts.Width
+	}
+	// Room for the widest art that fits and the column past it, or for a short line at about 8 columns a character
+	columns := min(opts.Width/sx, 8*len(line)) + 1
+
+	var segments []textSegment
+	start := 0
+	for start < len(line) {
+		// The space where a line was broken is not carried to the next line
+		if len(segments) > 0 {
+			for start < len(line) && line[start] == ' ' {
+				start++
+			}
+			if start == len(line) {
+				break
+			}
+		}
+
+		// Take as many whole words as fit, or the rest of the line
+		end, rest := fitWords(line, start, columns, font, opts.Fallback, s, fits)
+		if rest {
+			segments = append(segments, textSegment{text: line[start:], offset: offset + start})
+			break
+		}
+		if end > start {
+			segments = append(segments, textSegment{text: line[start:end], offset: offset + start})
+			start = end
+			continue
+		}
+
+		// A single word is too wide: break it between characters
+		seg := breakWord(line, start, columns, opts.Hyphenate, font, opts.Fallback, s, fits)
+		seg.offset += offset
+		segments = append(segments, seg)
+		start += len(seg.text)
+	}
+
+	if len(segments) == 0 {
+		return []textSegment{whole}
+	}
+	return segments
+}
+
+// fitWords lays out line from start, in rows with room for columns, until the art no longer fits
+// Returns where the last whole word that fits ends, and whether the rest of the line fits.
+func fitWords(line string, start, columns int, font *Font, fallback Fallback, s smusher, fits func(width int) bool) (int, bool) {
+	layout := newGlyphLayout(font.Height, columns, s)
+	end := start
+	for i, ch := range line[start:] {
+		i += start
+		if i > start && ch == ' ' && line[i-1] != ' ' {
+			end = i
+		}
+
+		if glyph, ok := fallback.place(font, ch, i); ok {
+			layout.add(glyph)
+		}
+		// Glyphs never make the art narrower, so nothing further along fits either
+		if !fits(layout.width()) {
+			return end, false
+		}
+	}
+	return end, true
+}
+
+// breakWord returns the longest prefix of line[start:] that fits, trying with a hyphen first
+// when hyphen is set. At least one character is always taken. The offset is relative to line.
+// Like fitWords, the prefix is laid out in rows with room for columns.
+func breakWord(line string, start, columns int, hyphen bool, font *Font, fallback Fallback, s smusher, fits func(width int) bool) textSegment {
+	dash, hasDash := fallback.place(font, '-', -1)
+	layout := newGlyphLayout(font.Height, columns, s)
+
+	best, bestHyphen := start, start
+	for end := start; end < len(line); {
+		ch, size := utf8.DecodeRuneInString(line[end:])
+		if glyph, ok := fallback.place(font, ch, end); ok {
+			layout.add(glyph)
+		}
+		end += size
+
+		// The hyphen is drawn after the prefix, so it is measured as the next glyph
+		if hyphen {
+			width := layout.width()
+			if hasDash {
+				width = layout.widthWith(dash)
+			}
+			if fits(width) {
+				bestHyphen = end
+			} else {
+				hyphen = false
+			}
+		}
+
+		if !fits(layout.width()) {
+			break
+		}
+		best = end
+	}
+
+	if bestHyphen > start {
+		return textSegment{text: line[start:bestHyphen], offset: start, hyphen: true}
+	}
+	if best > start {
+		return textSegment{text: line[start:best], offset: start}
+	}
+
+	_, size := utf8.DecodeRuneInString(line[start:])
+	return textSegment{text: line[start : start+size], offset: start}
+}
+
+// WrappedLine is one of the lines of art a line of text wraps to
+type WrappedLine struct {
+	Text   string // Text drawn on the line
+	Offset int    // Byte offset of Text in the line of text
+	Hyphen bool   // Whether a hyphen is drawn after Text
+}
+
+// WrapSegments splits a line of text into the lines it is rendered as when wrapped to opts.Width
+func WrapSegments(line string, font *Font, opts Options) []WrappedLine {
+	mode, rules := opts.horizontalLayout(font)
+	s := smusher{mode: mode, rules: rules, hardblank: font.Hardblank, spacing: opts.LetterSpacing}
+
+	segments := wrapLine(line, 0, font, opts, s)
+	wrapped := make([]WrappedLine, len(segments))
+	for i, seg := range segments {
+		wrapped[i] = WrappedLine{Text: seg.text, Offset: seg.offset, Hyphen: seg.hyphen}
+	}
+	return wrapped
+}
+
+// WrapLine splits a line of text into the lines it is rendered as when wrapped to opts.Width
+// A hyphen added by hyphenation is included in the text
+func WrapLine(line string, font *Font, opts Options) []string {
+	segments := WrapSegments(line, font, opts)
+	lines := make([]string, len(segments))
+	for i, seg := range segments {
+		lines[i] = seg.Text
+		if seg.Hyphen {
+			lines[i] += "-"
+		}
+	}
+	return lines
+}
@@ -3,7 +3,10 @@ package files
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"strings"
 )
 
 func ReadBannerLines(path string) ([]string, error) {
@@ -16,11 +19,28 @@ func ReadBannerLines(path string) ([]string, error) {
 	}
 	defer file.Close() // Close the file when the function exits
 
+	return readLines(file, path)
+}
+
+// ReadBannerLinesFS reads the lines of the file called name in fsys
+// Used for banners embedded in the binary as well as banner directories on disk
+func ReadBannerLinesFS(fsys fs.FS, name string) ([]string, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readLines(file, name)
+}
+
+// readLines scans r line by line; path is only used in error messages
+func readLines(r io.Reader, path string) ([]string, error) {
 	// Create a slice to hold the lines from the file
 	var lines []string
 
 	// Create a scanner to read the file line by line
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		// Append each line to the lines slice
 		lines = append(lines, scanner.Text())
@@ -36,6 +56,9 @@ func ReadBannerLines(path string) ([]string, error) {
 		return nil, fmt.Errorf("file %s is empty", path)
 	}
 
+	// Drop a UTF-8 byte order mark, which some editors add to Unicode font files
+	lines[0] = strings.TrimPrefix(lines[0], "\uFEFF")
+
 	// Return the slice of lines and nil error on success
 	return lines, nil
 }
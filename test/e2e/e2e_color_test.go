@@ -45,6 +45,18 @@ func TestColorCLI(t *testing.T) {
 			expectError: false,
 			hasColor:    true,
 		},
+		{
+			name:        "Justified color",
+			args:        []string{"--align=justify", "--width=60", "--color=red", "a b"},
+			expectError: false,
+			hasColor:    true,
+		},
+		{
+			name:        "Justified border color",
+			args:        []string{"--align=justify", "--width=60", "--border=single", "--border-color=blue", "a b"},
+			expectError: false,
+			hasColor:    true,
+		},
 		{
 			name:        "No color (backwards compatible)",
 			args:        []string{"Plain"},
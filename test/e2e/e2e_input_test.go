@@ -0,0 +1,82 @@
+package e2e
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInputFromStdinAndFile(t *testing.T) {
+	tempDir := t.TempDir()
+	binary := buildBinary(t, tempDir)
+
+	// Rendering the text from the command line is the reference output
+	cmd := exec.Command(binary, "deploy ok\\nline two", "shadow")
+	want, err := cmd.Output()
+	if err != nil {
+		t.Fatal("Program failed:", err)
+	}
+
+	// Piped text with --banner
+	cmd = exec.Command(binary, "--banner=shadow")
+	cmd.Stdin = strings.NewReader("deploy ok\nline two\n")
+	got, err := cmd.CombinedOutput()
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("Piped input differs from argument input (%v)\ngot:\n%s\nwant:\n%s", err, got, want)
+	}
+
+	// A lone banner name picks the banner for piped text instead of being rendered
+	cmd = exec.Command(binary, "shadow")
+	cmd.Stdin = strings.NewReader("deploy ok\nline two\n")
+	got, err = cmd.CombinedOutput()
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("Piped input with a banner argument differs from argument input (%v)\ngot:\n%s", err, got)
+	}
+
+	// --input=- reads stdin explicitly
+	cmd = exec.Command(binary, "--input=-", "shadow")
+	cmd.Stdin = strings.NewReader("deploy ok\nline two")
+	got, err = cmd.CombinedOutput()
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("--input=- differs from argument input (%v)\ngot:\n%s", err, got)
+	}
+
+	// --input=<file> with --output writes the same art to the output file
+	notes := filepath.Join(tempDir, "notes.txt")
+	if err := os.WriteFile(notes, []byte("deploy ok\nline two\n"), 0644); err != nil {
+		t.Fatal("Failed to write input file:", err)
+	}
+	outFile := filepath.Join(tempDir, "out.txt")
+	cmd = exec.Command(binary, "--input="+notes, "--output="+outFile, "--banner=shadow")
+	if out, err := cmd.CombinedOutput(); err != nil || len(out) != 0 {
+		t.Fatalf("Program failed: %v\n%s", err, out)
+	}
+	got, err = os.ReadFile(outFile)
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("--input with --output differs from argument input (%v)\ngot:\n%s", err, got)
+	}
+
+	// Parallel rendering writes the same bytes
+	cmd = exec.Command(binary, "--input="+notes, "--workers=3", "shadow")
+	got, err = cmd.CombinedOutput()
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("--workers output differs from argument input (%v)\ngot:\n%s", err, got)
+	}
+
+	// Color applies to streamed text
+	cmd = exec.Command(binary, "--input="+notes, "--color=red", "ok")
+	got, _ = cmd.CombinedOutput()
+	if !bytes.Contains(got, []byte("\033[38;2;255;0;0m")) {
+		t.Errorf("Expected colored output, got:\n%s", got)
+	}
+
+	// A missing file is reported
+	cmd = exec.Command(binary, "--input="+filepath.Join(tempDir, "missing.txt"))
+	got, _ = cmd.CombinedOutput()
+	if !bytes.Contains(got, []byte("failed to open input")) {
+		t.Errorf("Expected an open error, got:\n%s", got)
+	}
+}
@@ -40,3 +40,118 @@ func TestAsciiArtEndToEnd(t *testing.T) {
 		t.Error("Output file is empty, expected ASCII art output")
 	}
 }
+
+// buildBinary compiles the program into dir and returns the path of the executable
+func buildBinary(t *testing.T, dir string) string {
+	t.Helper()
+
+	binary := filepath.Join(dir, "ascii-art")
+	build := exec.Command("go", "build", "-o", binary, "./cmd")
+	build.Dir = filepath.Join("..", "..")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Build failed: %v\n%s", err, out)
+	}
+	return binary
+}
+
+func TestWrappingOnlyWithWidthOffTerminal(t *testing.T) {
+	binary := buildBinary(t, t.TempDir())
+	text := "The quick brown fox jumps over the lazy dog"
+
+	// Piped output is never wrapped to a terminal width, so it stays as wide as the art
+	piped, err := exec.Command(binary, text).Output()
+	if err != nil {
+		t.Fatal("Program failed:", err)
+	}
+	if lines := bytes.Split(bytes.TrimSuffix(piped, []byte("\n")), []byte("\n")); len(lines) != 8 || len(lines[0]) <= 80 {
+		t.Errorf("Piped output has %d rows of %d columns, want 8 unwrapped rows", len(lines), len(lines[0]))
+	}
+
+	// --width wraps it anyway
+	wrapped, err := exec.Command(binary, "--width=80", text).Output()
+	if err != nil {
+		t.Fatal("Program failed:", err)
+	}
+	lines := bytes.Split(bytes.TrimSuffix(wrapped, []byte("\n")), []byte("\n"))
+	if len(lines) <= 8 {
+		t.Errorf("--width=80 output has %d rows, want the art wrapped onto several lines", len(lines))
+	}
+	for _, line := range lines {
+		if len(line) > 80 {
+			t.Errorf("--width=80 output has a row of %d columns", len(line))
+		}
+	}
+}
+
+func TestBuiltinBannersOutsideRepoRoot(t *testing.T) {
+	// Build the binary and run it from a directory without a banners folder
+	tempDir := t.TempDir()
+	binary := buildBinary(t, tempDir)
+
+	cmd := exec.Command(binary, "Hi", "shadow")
+	cmd.Dir = tempDir
+	rendered, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Program failed: %v\n%s", err, rendered)
+	}
+	if !bytes.Contains(rendered, []byte("_|    _|")) {
+		t.Fatalf("Expected shadow banner art, got:\n%s", rendered)
+	}
+
+	// Reverse also finds the built-in banners
+	artFile := filepath.Join(tempDir, "art.txt")
+	if err := os.WriteFile(artFile, rendered, 0644); err != nil {
+		t.Fatal("Failed to write art file:", err)
+	}
+
+	cmd = exec.Command(binary, "--reverse=art.txt")
+	cmd.Dir = tempDir
+	text, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Reverse failed: %v\n%s", err, text)
+	}
+	if string(bytes.TrimSpace(text)) != "Hi" {
+		t.Errorf("Expected reverse output %q, got %q", "Hi", text)
+	}
+}
+
+func TestBannerDirFlag(t *testing.T) {
+	tempDir := t.TempDir()
+	binary := buildBinary(t, tempDir)
+
+	// A one row banner where every glyph is drawn with its own character
+	bannerDir := filepath.Join(tempDir, "fonts")
+	if err := os.Mkdir(bannerDir, 0755); err != nil {
+		t.Fatal("Failed to create banner directory:", err)
+	}
+	var banner bytes.Buffer
+	banner.WriteString("# height: 1\n")
+	for ch := rune(32); ch <= 126; ch++ {
+		banner.WriteString(string(ch) + "\n\n")
+	}
+	if err := os.WriteFile(filepath.Join(bannerDir, "tiny.txt"), banner.Bytes(), 0644); err != nil {
+		t.Fatal("Failed to write banner:", err)
+	}
+
+	cmd := exec.Command(binary, "--banner-dir="+bannerDir, "Hi", "tiny")
+	cmd.Dir = tempDir
+	rendered, err := cmd.CombinedOutput()
+	if err != nil || string(rendered) != "Hi\n" {
+		t.Fatalf("Expected %q, got %q (%v)", "Hi\n", rendered, err)
+	}
+
+	// The same registry feeds the invalid banner message
+	cmd = exec.Command(binary, "Hi", "tiny")
+	cmd.Dir = tempDir
+	out, _ := cmd.CombinedOutput()
+	if !bytes.Contains(out, []byte("invalid banner style")) {
+		t.Errorf("Expected tiny to be unknown without --banner-dir, got:\n%s", out)
+	}
+
+	cmd = exec.Command(binary, "--banner-dir="+bannerDir, "Hi", "missing")
+	cmd.Dir = tempDir
+	out, _ = cmd.CombinedOutput()
+	if !bytes.Contains(out, []byte("shadow, standard, thinkertoy, tiny")) {
+		t.Errorf("Expected the error to list tiny, got:\n%s", out)
+	}
+}
@@ -1,6 +1,7 @@
 package unit
 
 import (
+	"ascii-art/internal/ascii"
 	justify "ascii-art/internal/ascii-justify"
 	"strings"
 	"testing"
@@ -198,7 +199,7 @@ func TestApplyAlignment(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := justify.ApplyAlignment(tt.lines, tt.alignType, tt.termWidth)
+			result := justify.ApplyAlignment(tt.lines, tt.alignType, tt.termWidth, ascii.DefaultHeight)
 			if !tt.checkFunc(result) {
 				t.Errorf("Alignment check failed for %s", tt.name)
 				t.Logf("Result: %v", result)
@@ -282,3 +283,50 @@ func TestJustifyChunk(t *testing.T) {
 		t.Errorf("Expected %d lines, got %d", len(chunk), len(result))
 	}
 }
+
+func TestAlignRendered(t *testing.T) {
+	lines := []string{"AB", "CD"}
+
+	tests := []struct {
+		name      string
+		alignType string
+		expected  []string
+	}{
+		{
+			name:      "Left keeps lines unchanged",
+			alignType: "left",
+			expected:  []string{"AB", "CD"},
+		},
+		{
+			name:      "Center pads rendered lines",
+			alignType: "center",
+			expected:  []string{"    AB", "    CD"},
+		},
+		{
+			name:      "Right pads rendered lines",
+			alignType: "right",
+			expected:  []string{"        AB", "        CD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := justify.AlignRendered(lines, tt.alignType, "", ascii.NewFont("test", nil), ascii.Options{}, 10)
+			if !equalSlices(result, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestHandleJustifyWritesToWriter(t *testing.T) {
+	var sb strings.Builder
+	err := justify.HandleJustify(&sb, []string{"AB", "CD"}, "left", "", ascii.NewFont("test", nil), ascii.Options{})
+	if err != nil {
+		t.Fatalf("HandleJustify() unexpected error = %v", err)
+	}
+
+	if sb.String() != "AB\nCD\n" {
+		t.Errorf("Expected %q, got %q", "AB\nCD\n", sb.String())
+	}
+}
@@ -0,0 +1,153 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func listRegistry() *ascii.Registry {
+	return &ascii.Registry{Sources: []ascii.FontSource{
+		{Name: "fonts", FS: fstest.MapFS{
+			"tiny.txt":   {Data: bannerFile([]string{"# author: Ann", "# description: One row", "# height: 1"}, 1, map[rune][]string{'!': {"!"}})},
+			"broken.flf": {Data: []byte("not a font\n")},
+		}},
+		{Name: ascii.BuiltinSource, FS: fstest.MapFS{
+			"tiny.txt": {Data: []byte("# height: 2\n \n \n")},
+			"dot.txt":  {Data: bannerFile([]string{"# name: Dot", "# layout: fit"}, 1, map[rune][]string{'!': {"."}})},
+		}},
+	}}
+}
+
+func TestRegistryList(t *testing.T) {
+	infos := listRegistry().List()
+
+	var names, paths []string
+	for _, info := range infos {
+		names = append(names, info.Name)
+		paths = append(paths, info.Path)
+	}
+	if want := []string{"broken", "dot", "tiny"}; !equalSlices(names, want) {
+		t.Fatalf("List() names = %v, want %v", names, want)
+	}
+
+	// The first source holding a banner wins, as it does for rendering
+	if want := []string{"fonts/broken.flf", "dot.txt (built-in)", "fonts/tiny.txt"}; !equalSlices(paths, want) {
+		t.Errorf("List() paths = %v, want %v", paths, want)
+	}
+	if infos[0].Err == nil {
+		t.Error("List() loaded a broken banner without an error")
+	}
+	if infos[2].Font == nil || infos[2].Font.Height != 1 {
+		t.Errorf("List() did not load tiny from the first source")
+	}
+}
+
+func TestWriteBannerList(t *testing.T) {
+	var sb strings.Builder
+	if err := ascii.WriteBannerList(&sb, listRegistry().List()); err != nil {
+		t.Fatalf("WriteBannerList() unexpected error = %v", err)
+	}
+
+	got := strings.Split(sb.String(), "\n")
+	want := []string{
+		"broken",
+		"  source: fonts/broken.flf",
+		"",
+		"dot",
+		"  height: 1",
+		"  glyphs: 95",
+		"  source: dot.txt (built-in)",
+		"  name: Dot",
+		"  layout: fit",
+		"  vlayout: full",
+		"",
+		"tiny",
+		"  height: 1",
+		"  glyphs: 95",
+		"  source: fonts/tiny.txt",
+		"  author: Ann",
+		"  description: One row",
+		"  layout: full",
+		"  vlayout: full",
+		"",
+	}
+	if len(got) != len(want)+1 || !strings.HasPrefix(got[2], "  error: ") {
+		t.Fatalf("WriteBannerList() = %q", got)
+	}
+	if got = append(got[:2], got[3:]...); !equalSlices(got, want) {
+		t.Errorf("WriteBannerList() = %q, want %q", got, want)
+	}
+}
+
+func TestWritePreview(t *testing.T) {
+	var sb strings.Builder
+	infos := listRegistry().List()[1:]
+	if err := ascii.WritePreview(&sb, infos, "!", ascii.Options{}); err != nil {
+		t.Fatalf("WritePreview() unexpected error = %v", err)
+	}
+
+	want := "dot:\n.\n\ntiny:\n!\n"
+	if got := sb.String(); got != want {
+		t.Errorf("WritePreview() = %q, want %q", got, want)
+	}
+
+	// Banners missing a character show why when the fallback policy asks for an error
+	sb.Reset()
+	opts := ascii.Options{Fallback: ascii.Fallback{Mode: ascii.FallbackError}}
+	if err := ascii.WritePreview(&sb, infos, "#", opts); err != nil {
+		t.Fatalf("WritePreview() unexpected error = %v", err)
+	}
+	got := sb.String()
+	if !strings.HasPrefix(got, "dot:\n") || !strings.Contains(got, "\ntiny:\n") || strings.Contains(got, strings.Repeat(" ", 8)) {
+		t.Errorf("WritePreview() = %q, want the missing glyph errors", got)
+	}
+}
+
+func TestParseListBannersFlag(t *testing.T) {
+	list, rest, err := ascii.ParseListBannersFlag([]string{"--width=20", "--list-banners"})
+	if err != nil || !list || !equalSlices(rest, []string{"--width=20"}) {
+		t.Errorf("ParseListBannersFlag() = %v, %q, %v", list, rest, err)
+	}
+
+	if list, rest, err := ascii.ParseListBannersFlag([]string{"Hi"}); err != nil || list || !equalSlices(rest, []string{"Hi"}) {
+		t.Errorf("ParseListBannersFlag() without the switch = %v, %q, %v", list, rest, err)
+	}
+	if _, _, err := ascii.ParseListBannersFlag([]string{"--list-banners=yes"}); err == nil {
+		t.Error("ParseListBannersFlag() expected an error for a value")
+	}
+}
+
+func TestParsePreviewFlag(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		want  string
+		rest  []string
+		isErr bool
+	}{
+		{name: "bare", args: []string{"--preview", "--scale=2"}, want: ascii.DefaultPreviewText, rest: []string{"--scale=2"}},
+		{name: "text", args: []string{"--preview=Hi there"}, want: "Hi there"},
+		{name: "absent", args: []string{"Hi"}, rest: []string{"Hi"}},
+		{name: "empty", args: []string{"--preview="}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := ascii.ParsePreviewFlag(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParsePreviewFlag() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePreviewFlag() unexpected error = %v", err)
+			}
+			if got != tt.want || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParsePreviewFlag() = %q, %q", got, rest)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// benchmarkText returns about size bytes of printable text in lines of 80 characters
+func benchmarkText(size int) string {
+	line := strings.Repeat("The quick brown fox jumps over the lazy dog 0123456789 !?", 2)[:80]
+	lines := make([]string, size/(len(line)+1))
+	for i := range lines {
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// benchmarkFont loads a built-in banner for a benchmark
+func benchmarkFont(b *testing.B, name string) *ascii.Font {
+	b.Helper()
+	font, err := ascii.LoadBannerFile(name)
+	if err != nil {
+		b.Fatalf("LoadBannerFile(%q) unexpected error = %v", name, err)
+	}
+	return font
+}
+
+func BenchmarkRenderLine(b *testing.B) {
+	font := benchmarkFont(b, "standard")
+	text := benchmarkText(81)
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for b.Loop() {
+		ascii.Render(text, font, ascii.Options{}).Lines()
+	}
+}
+
+func BenchmarkRenderMegabytes(b *testing.B) {
+	font := benchmarkFont(b, "standard")
+	text := benchmarkText(2 << 20)
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for b.Loop() {
+		ascii.WriteLines(io.Discard, ascii.RenderPlain(text, font, ascii.Options{}))
+	}
+}
+
+func BenchmarkRenderCellsMegabytes(b *testing.B) {
+	font := benchmarkFont(b, "standard")
+	text := benchmarkText(2 << 20)
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for b.Loop() {
+		ascii.WriteLines(io.Discard, ascii.Render(text, font, ascii.Options{}).Lines())
+	}
+}
+
+func BenchmarkRenderWrappedLine(b *testing.B) {
+	font := benchmarkFont(b, "standard")
+	text := strings.ReplaceAll(benchmarkText(64<<10), "\n", " ")
+	opts := ascii.Options{Width: 80, Hyphenate: true}
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for b.Loop() {
+		ascii.WriteLines(io.Discard, ascii.Render(text, font, opts).Lines())
+	}
+}
+
+func BenchmarkRenderSmushMegabytes(b *testing.B) {
+	font := benchmarkFont(b, "standard")
+	text := benchmarkText(2 << 20)
+	opts := ascii.Options{Layout: ascii.LayoutSmush, VLayout: ascii.LayoutSmush}
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for b.Loop() {
+		ascii.WriteLines(io.Discard, ascii.Render(text, font, opts).Lines())
+	}
+}
+
+func BenchmarkColorizeMegabytes(b *testing.B) {
+	font := benchmarkFont(b, "shadow")
+	text := benchmarkText(2 << 20)
+	config := color.ColorConfig{Enabled: true, Color: "red", Substring: "fox"}
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for b.Loop() {
+		lines, err := color.Colorize(ascii.Render(text, font, ascii.Options{}), text, config)
+		if err != nil {
+			b.Fatal(err)
+		}
+		ascii.WriteLines(io.Discard, lines)
+	}
+}
+
+func BenchmarkStreamMegabytes(b *testing.B) {
+	font := benchmarkFont(b, "standard")
+	lines := strings.Split(benchmarkText(2<<20), "\n")
+
+	b.SetBytes(int64(len(lines) * 81))
+	b.ReportAllocs()
+	for b.Loop() {
+		stream := ascii.NewStream(font, ascii.Options{})
+		for _, line := range lines {
+			ascii.WriteLines(io.Discard, stream.Add(line).Lines())
+		}
+		ascii.WriteLines(io.Discard, stream.Flush().Lines())
+	}
+}
+
+func BenchmarkRenderParallelMegabytes(b *testing.B) {
+	font := benchmarkFont(b, "standard")
+	text := benchmarkText(2 << 20)
+	opts := ascii.Options{Workers: runtime.NumCPU()}
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for b.Loop() {
+		ascii.WriteLines(io.Discard, ascii.Render(text, font, opts).Lines())
+	}
+}
@@ -0,0 +1,256 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	input "ascii-art/internal/ascii-input"
+	justify "ascii-art/internal/ascii-justify"
+	"strings"
+	"testing"
+)
+
+func borderFont() *ascii.Font {
+	return ascii.NewFont("border", map[rune][]string{
+		'a': {"a", "a"},
+		'b': {"bb", "bb"},
+		' ': {" ", " "},
+	})
+}
+
+func TestRenderBorder(t *testing.T) {
+	font := borderFont()
+	single := ascii.BorderNames["single"]
+
+	tests := []struct {
+		name   string
+		text   string
+		border ascii.Border
+		want   []string
+	}{
+		{name: "none", text: "ab", want: []string{"abb", "abb"}},
+		{name: "frame", text: "ab", border: ascii.Border{Chars: single}, want: []string{
+			"┌───┐",
+			"│abb│",
+			"│abb│",
+			"└───┘",
+		}},
+		{name: "rows padded to the widest", text: "b\na", border: ascii.Border{Chars: ascii.BorderNames["ascii"]}, want: []string{
+			"+--+",
+			"|bb|",
+			"|bb|",
+			"|a |",
+			"|a |",
+			"+--+",
+		}},
+		{name: "padding and margin", text: "a", border: ascii.Border{
+			Chars:   single,
+			Padding: ascii.Box{Top: 1, Right: 2, Left: 1},
+			Margin:  ascii.Box{Top: 1, Left: 2, Bottom: 1},
+		}, want: []string{
+			"        ",
+			"  ┌────┐",
+			"  │    │",
+			"  │ a  │",
+			"  │ a  │",
+			"  └────┘",
+			"        ",
+		}},
+		{name: "padding without a frame", text: "a", border: ascii.Border{Padding: ascii.Box{Right: 1, Left: 1}}, want: []string{" a ", " a "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ascii.Render(tt.text, font, ascii.Options{Border: tt.border}).Lines()
+			if !equalSlices(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBorderSizeAndWidth(t *testing.T) {
+	font := borderFont()
+	border := ascii.Border{Chars: ascii.BorderNames["double"], Padding: ascii.Box{Right: 1, Left: 1}, Margin: ascii.Box{Top: 1}}
+
+	if columns, rows := border.Size(); columns != 4 || rows != 3 {
+		t.Errorf("Size() = %d, %d, want 4, 3", columns, rows)
+	}
+
+	// The measured size includes the border
+	width, height := justify.MeasureText("ab", font, ascii.Options{Border: border})
+	if width != 7 || height != 5 {
+		t.Errorf("MeasureText() = %dx%d, want 7x5", width, height)
+	}
+
+	// --width counts the border, so the art inside wraps sooner
+	canvas := ascii.Render("ab ab", font, ascii.Options{Border: border, Width: 9})
+	if canvas.Width() > 9 || canvas.Height() != 7 {
+		t.Errorf("Render() wrapped = %dx%d, want two lines of text at most 9 wide", canvas.Width(), canvas.Height())
+	}
+}
+
+func TestBorderColor(t *testing.T) {
+	font := borderFont()
+	canvas := ascii.Render("ab", font, ascii.Options{Border: ascii.Border{Chars: ascii.BorderNames["ascii"]}})
+	red, _ := color.ParseColor("red")
+	blue, _ := color.ParseColor("blue")
+	reset := color.ResetColor()
+
+	// Only the border is colored
+	lines, err := color.Colorize(canvas, "ab", color.ColorConfig{Border: "red"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	if want := red + "+---+" + reset; lines[0] != want {
+		t.Errorf("top = %q, want %q", lines[0], want)
+	}
+	if want := red + "|" + reset + "abb" + red + "|" + reset; lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+
+	// The text and the border keep their own colors
+	lines, err = color.Colorize(canvas, "ab", color.ColorConfig{Enabled: true, Color: "blue", Substring: "b", Border: "red"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	if want := red + "|" + reset + "a" + blue + "bb" + red + "|" + reset; lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+
+	if _, err := color.Colorize(canvas, "ab", color.ColorConfig{Border: "nope"}); err == nil {
+		t.Error("Colorize() expected an error for an unknown border color")
+	}
+}
+
+// TestBorderColorJustified checks that justified words keep their sources, so the text and frame are still colored
+func TestBorderColorJustified(t *testing.T) {
+	font := borderFont()
+	opts := ascii.Options{Border: ascii.Border{Chars: ascii.BorderNames["ascii"]}}
+	config := color.ColorConfig{Enabled: true, Color: "blue", Substring: "b", Border: "red"}
+	red, _ := color.ParseColor("red")
+	blue, _ := color.ParseColor("blue")
+
+	canvas := justify.JustifyCanvas("a b", 0, font, opts, 20)
+	lines, err := color.Colorize(canvas, "a b", config)
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	if !strings.HasPrefix(lines[0], red+"+---") || !strings.HasPrefix(lines[1], red+"|") {
+		t.Errorf("justified frame = %q, want it red", lines[:2])
+	}
+	if !strings.Contains(lines[1], blue+"bb") || strings.Contains(lines[1], blue+"a") {
+		t.Errorf("justified row = %q, want only b blue", lines[1])
+	}
+
+	// Streamed lines are justified one by one, with sources counted through the whole input
+	var out strings.Builder
+	cfg := input.StreamConfig{Font: font, Options: opts, Color: config, Align: "justify", Width: 20}
+	if err := input.HandleStream(strings.NewReader("a\nb\n"), &out, cfg); err != nil {
+		t.Fatalf("HandleStream() unexpected error = %v", err)
+	}
+	streamed := strings.Split(out.String(), "\n")
+	if !strings.HasPrefix(streamed[0], red+"+---") {
+		t.Errorf("streamed frame = %q, want it red", streamed[0])
+	}
+	if strings.Contains(streamed[1], blue) || !strings.Contains(streamed[3], blue+"bb") {
+		t.Errorf("streamed rows = %q, want only b blue", streamed[1:5])
+	}
+}
+
+func TestBorderStreamsWholeBlock(t *testing.T) {
+	font := standardFont(t)
+	opts := ascii.Options{Border: ascii.Border{Chars: ascii.BorderNames["rounded"]}}
+
+	stream := ascii.NewStream(font, opts)
+	for _, line := range []string{"ab", "cd"} {
+		if rows := stream.Add(line); rows.Height() != 0 {
+			t.Errorf("Add(%q) returned %d rows before the block was complete", line, rows.Height())
+		}
+	}
+	got := stream.Flush().Lines()
+	if want := ascii.Render("ab\ncd", font, opts).Lines(); !equalSlices(got, want) {
+		t.Errorf("Flush() = %q, want %q", got, want)
+	}
+}
+
+func TestBorderAlignsAsUnit(t *testing.T) {
+	font := standardFont(t)
+	opts := ascii.Options{Border: ascii.Border{Chars: ascii.BorderNames["single"]}}
+	lines := ascii.Render("a\nbcd", font, opts).Lines()
+
+	// Every row of the block is equally wide, so centring moves the block without changing its shape
+	centered := justify.ApplyAlignment(lines, "center", 80, font.Height)
+	indent := len(centered[0]) - len(lines[0])
+	for i, line := range centered {
+		if line != strings.Repeat(" ", indent)+lines[i] {
+			t.Errorf("line %d = %q, want %q indented by %d", i, line, lines[i], indent)
+		}
+	}
+
+	// Justified words spread out inside a frame as wide as the terminal
+	justified := justify.RenderWithJustify("ab cd", font, opts, 60)
+	for i, line := range justified {
+		if width := justify.GetLineWidth(line); width != 60 {
+			t.Errorf("justified line %d is %d wide, want 60", i, width)
+		}
+	}
+	if !strings.HasPrefix(justified[0], "┌─") || !strings.HasPrefix(justified[len(justified)-1], "└─") {
+		t.Errorf("RenderWithJustify() = %q, want it framed", justified)
+	}
+}
+
+func TestParseBorderFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		want  ascii.Border
+		rest  []string
+		isErr bool
+	}{
+		{name: "style", args: []string{"--border=heavy", "Hi"}, want: ascii.Border{Chars: ascii.BorderNames["heavy"]}, rest: []string{"Hi"}},
+		{name: "custom", args: []string{"--border=custom:*=*!!*=*"}, want: ascii.Border{Chars: [8]rune{'*', '=', '*', '!', '!', '*', '=', '*'}}},
+		{name: "padding and margin", args: []string{"--padding=1,2", "Hi", "--margin=1,2,3,4"}, want: ascii.Border{
+			Padding: ascii.Box{Top: 1, Right: 2, Bottom: 1, Left: 2},
+			Margin:  ascii.Box{Top: 1, Right: 2, Bottom: 3, Left: 4},
+		}, rest: []string{"Hi"}},
+		{name: "border color is left alone", args: []string{"--border-color=red"}, rest: []string{"--border-color=red"}},
+		{name: "absent", args: []string{"Hi"}, rest: []string{"Hi"}},
+		{name: "missing =", args: []string{"--border", "single"}, isErr: true},
+		{name: "empty", args: []string{"--padding="}, isErr: true},
+		{name: "unknown style", args: []string{"--border=dotted"}, isErr: true},
+		{name: "short custom", args: []string{"--border=custom:+-+|"}, isErr: true},
+		{name: "three sizes", args: []string{"--margin=1,2,3"}, isErr: true},
+		{name: "negative", args: []string{"--padding=-1"}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := ascii.ParseBorderFlags(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParseBorderFlags() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBorderFlags() unexpected error = %v", err)
+			}
+			if got != tt.want || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParseBorderFlags() = %+v, %q", got, rest)
+			}
+		})
+	}
+}
+
+func TestParseBorderColorFlag(t *testing.T) {
+	got, rest, err := color.ParseBorderColorFlag([]string{"Hi", "--border-color=#00ff00"})
+	if err != nil || got != "#00ff00" || !equalSlices(rest, []string{"Hi"}) {
+		t.Errorf("ParseBorderColorFlag() = %q, %q, %v", got, rest, err)
+	}
+
+	for _, args := range [][]string{{"--border-color"}, {"--border-color="}, {"--border-color=nope"}} {
+		if _, _, err := color.ParseBorderColorFlag(args); err == nil {
+			t.Errorf("ParseBorderColorFlag(%q) expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	"testing"
+)
+
+func directionFont() *ascii.Font {
+	return ascii.NewFont("direction", map[rune][]string{
+		'w': {"www", "www"},
+		'i': {"i", "i"},
+		' ': {" ", " "},
+	})
+}
+
+func TestRenderVertical(t *testing.T) {
+	font := directionFont()
+	opts := ascii.Options{Direction: ascii.DirectionVertical}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "glyphs stacked and centred", input: "wi", want: []string{"www", "www", " i ", " i "}},
+		{name: "lines side by side", input: "i\nwi", want: []string{"iwww", "iwww", "  i ", "  i "}},
+		{name: "empty line", input: "i\n\ni", want: []string{"i i", "i i"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ascii.Render(tt.input, font, opts).Lines(); !equalSlices(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerticalMeasureAndColor(t *testing.T) {
+	font := standardFont(t)
+	opts := ascii.Options{Direction: ascii.DirectionVertical}
+
+	width, height := justify.MeasureText("Wi", font, opts)
+	if width != font.Width('W') || height != 2*font.Height {
+		t.Errorf("MeasureText() = %dx%d, want %dx%d", width, height, font.Width('W'), 2*font.Height)
+	}
+
+	// Only the rows of the i are colored
+	canvas := ascii.Render("Wi", font, opts)
+	lines, err := color.Colorize(canvas, "Wi", color.ColorConfig{Enabled: true, Color: "red", Substring: "i"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	red, _ := color.ParseColor("red")
+	for i, line := range lines {
+		if colored := contains(line, red); colored != (i >= font.Height) {
+			t.Errorf("line %d colored = %v: %q", i, colored, line)
+		}
+	}
+}
+
+func TestVerticalStreamsWholeBlock(t *testing.T) {
+	font := directionFont()
+	opts := ascii.Options{Direction: ascii.DirectionVertical, Workers: 2}
+
+	stream := ascii.NewStream(font, opts)
+	for _, line := range []string{"w", "i"} {
+		if rows := stream.Add(line); rows.Height() != 0 {
+			t.Errorf("Add(%q) returned %d rows before the columns were complete", line, rows.Height())
+		}
+	}
+	want := []string{"wwwi", "wwwi"}
+	if got := stream.Flush().Lines(); !equalSlices(got, want) {
+		t.Errorf("Flush() = %q, want %q", got, want)
+	}
+
+	// The parallel renderer gives the same columns
+	if got := ascii.Render("w\ni", font, opts).Lines(); !equalSlices(got, want) {
+		t.Errorf("Render() with workers = %q, want %q", got, want)
+	}
+}
+
+func TestJustifyCentresVerticalText(t *testing.T) {
+	font := directionFont()
+	opts := ascii.Options{Direction: ascii.DirectionVertical}
+
+	got := justify.RenderWithJustify("wi", font, opts, 9)
+	want := []string{"   www", "   www", "    i ", "    i "}
+	if !equalSlices(got, want) {
+		t.Errorf("RenderWithJustify() = %q, want %q", got, want)
+	}
+}
+
+func TestParseDirectionFlag(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		want  ascii.Direction
+		rest  []string
+		isErr bool
+	}{
+		{name: "vertical", args: []string{"--direction=vertical", "Hi"}, want: ascii.DirectionVertical, rest: []string{"Hi"}},
+		{name: "horizontal", args: []string{"--direction=horizontal"}, want: ascii.DirectionHorizontal},
+		{name: "absent", args: []string{"Hi"}, want: ascii.DirectionHorizontal, rest: []string{"Hi"}},
+		{name: "missing =", args: []string{"--direction", "vertical"}, isErr: true},
+		{name: "unknown", args: []string{"--direction=diagonal"}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := ascii.ParseDirectionFlag(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParseDirectionFlag() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDirectionFlag() unexpected error = %v", err)
+			}
+			if got != tt.want || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParseDirectionFlag() = %v, %q", got, rest)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	"strings"
+	"testing"
+)
+
+func effectFont() *ascii.Font {
+	return ascii.NewFont("effect", map[rune][]string{
+		'a': {"aaaa", "aaaa", "aaaa"},
+		'b': {"bb", "bb", "bb"},
+		'c': {"c ", " c", "  "},
+	})
+}
+
+func TestRenderEffect(t *testing.T) {
+	font := effectFont()
+
+	tests := []struct {
+		name   string
+		text   string
+		effect ascii.Effect
+		want   []string
+	}{
+		{name: "none", text: "a", want: []string{"aaaa", "aaaa", "aaaa"}},
+		{name: "hollow", text: "a", effect: ascii.Effect{Mode: ascii.EffectHollow}, want: []string{"aaaa", "a  a", "aaaa"}},
+		{name: "outline character", text: "a", effect: ascii.Effect{Mode: ascii.EffectOutline, Char: '#'}, want: []string{"####", "#  #", "####"}},
+		{name: "outline box", text: "a", effect: ascii.Effect{Mode: ascii.EffectOutline, Box: ascii.OutlineSets["single"]}, want: []string{"┌──┐", "│  │", "└──┘"}},
+		{name: "outline across glyphs", text: "ab", effect: ascii.Effect{Mode: ascii.EffectOutline, Box: ascii.OutlineSets["ascii"]}, want: []string{"+----+", "|    |", "+----+"}},
+		{name: "thin strokes are all edge", text: "c", effect: ascii.Effect{Mode: ascii.EffectHollow}, want: []string{"c ", " c", "  "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ascii.Render(tt.text, font, ascii.Options{Effect: tt.effect}).Lines()
+			if !equalSlices(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectKeepsColors(t *testing.T) {
+	font := effectFont()
+	canvas := ascii.Render("ab", font, ascii.Options{Effect: ascii.Effect{Mode: ascii.EffectOutline, Char: '#'}})
+	red, _ := color.ParseColor("red")
+	reset := color.ResetColor()
+
+	// The edge drawn by b is still colored as b
+	lines, err := color.Colorize(canvas, "ab", color.ColorConfig{Enabled: true, Color: "red", Substring: "b"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	want := []string{"####" + red + "##" + reset, "#   " + red + " #" + reset, "####" + red + "##" + reset}
+	if !equalSlices(lines, want) {
+		t.Errorf("Colorize() = %q, want %q", lines, want)
+	}
+}
+
+func TestEffectStreamsWholeBlock(t *testing.T) {
+	font := effectFont()
+	opts := ascii.Options{Effect: ascii.Effect{Mode: ascii.EffectHollow}}
+
+	stream := ascii.NewStream(font, opts)
+	for _, line := range []string{"a", "a"} {
+		if rows := stream.Add(line); rows.Height() != 0 {
+			t.Errorf("Add(%q) returned %d rows before the block was complete", line, rows.Height())
+		}
+	}
+
+	// The two lines touch, so they are hollowed as one region
+	got := stream.Flush().Lines()
+	if want := []string{"aaaa", "a  a", "a  a", "a  a", "a  a", "aaaa"}; !equalSlices(got, want) {
+		t.Errorf("Flush() = %q, want %q", got, want)
+	}
+}
+
+func TestJustifyEffectsWholeBlock(t *testing.T) {
+	font := standardFont(t)
+	effect := ascii.Effect{Mode: ascii.EffectOutline, Char: '*'}
+
+	plain := justify.RenderWithJustify("ab cd", font, ascii.Options{}, 80)
+	got := justify.RenderWithJustify("ab cd", font, ascii.Options{Effect: effect}, 80)
+	if want := ascii.EffectLines(plain, effect); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("RenderWithJustify() = %q, want %q", got, want)
+	}
+}
+
+func TestParseEffectFlag(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		want  ascii.Effect
+		rest  []string
+		isErr bool
+	}{
+		{name: "hollow", args: []string{"--effect=hollow", "Hi"}, want: ascii.Effect{Mode: ascii.EffectHollow}, rest: []string{"Hi"}},
+		{name: "outline", args: []string{"--effect=outline"}, want: ascii.Effect{Mode: ascii.EffectOutline, Box: ascii.OutlineSets["single"]}},
+		{name: "outline set", args: []string{"--effect=outline:heavy"}, want: ascii.Effect{Mode: ascii.EffectOutline, Box: ascii.OutlineSets["heavy"]}},
+		{name: "outline character", args: []string{"Hi", "--effect=outline:█"}, want: ascii.Effect{Mode: ascii.EffectOutline, Char: '█'}, rest: []string{"Hi"}},
+		{name: "absent", args: []string{"Hi"}, rest: []string{"Hi"}},
+		{name: "missing =", args: []string{"--effect", "hollow"}, isErr: true},
+		{name: "empty", args: []string{"--effect="}, isErr: true},
+		{name: "unknown", args: []string{"--effect=glow"}, isErr: true},
+		{name: "hollow with a style", args: []string{"--effect=hollow:#"}, isErr: true},
+		{name: "unknown set", args: []string{"--effect=outline:dotted"}, isErr: true},
+		{name: "space", args: []string{"--effect=outline: "}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := ascii.ParseEffectFlag(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParseEffectFlag() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseEffectFlag() unexpected error = %v", err)
+			}
+			if got != tt.want || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParseEffectFlag() = %+v, %q", got, rest)
+			}
+		})
+	}
+}
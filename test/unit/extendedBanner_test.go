@@ -0,0 +1,136 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	reverse "ascii-art/internal/ascii-reverse"
+	"strings"
+	"testing"
+)
+
+// extendedBannerLines returns a 2-row banner with the 95 classic glyphs followed by an extended section
+func extendedBannerLines(extended ...string) []string {
+	lines := []string{"# height: 2"}
+	for ch := rune(32); ch <= 126; ch++ {
+		switch ch {
+		case ' ':
+			lines = append(lines, "  ", "  ", "")
+		case 'a':
+			lines = append(lines, " a", "aa", "")
+		default:
+			lines = append(lines, "??", "??", "")
+		}
+	}
+	return append(lines, extended...)
+}
+
+func extendedFont(t *testing.T) *ascii.Font {
+	t.Helper()
+
+	font, err := ascii.ParseBanner("extended", extendedBannerLines(
+		"U+00E9 LATIN SMALL LETTER E WITH ACUTE",
+		" /",
+		"é ",
+		"",
+		"U+20AC",
+		"C=",
+		"C=",
+		"",
+		"u+2500",
+		"──",
+		"  ",
+	))
+	if err != nil {
+		t.Fatalf("ParseBanner() unexpected error = %v", err)
+	}
+	return font
+}
+
+func TestParseBannerExtendedSection(t *testing.T) {
+	font := extendedFont(t)
+
+	if len(font.Glyphs) != 95+3 {
+		t.Errorf("loaded %d glyphs, want %d", len(font.Glyphs), 95+3)
+	}
+	if got := font.Glyphs['é']; !equalSlices(got, []string{" /", "é "}) {
+		t.Errorf("glyph 'é' = %q", got)
+	}
+	if got := font.Glyphs['─']; !equalSlices(got, []string{"──", "  "}) || font.Width('─') != 2 {
+		t.Errorf("glyph '─' = %q, width %d", got, font.Width('─'))
+	}
+}
+
+func TestParseBannerExtendedSectionErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		extended []string
+		contains string
+	}{
+		{name: "missing codepoint header", extended: []string{"U+00E9", "xx", "xx", "", "xx", "xx"}, contains: "codepoint header"},
+		{name: "invalid codepoint", extended: []string{"U+00E9", "xx", "xx", "", "U+D800", "xx", "xx"}, contains: "codepoint header"},
+		{name: "short glyph", extended: []string{"U+00E9", "xx"}, contains: "needs 2 rows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ascii.ParseBanner("bad", extendedBannerLines(tt.extended...))
+			if err == nil || !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("ParseBanner() error = %v, want one containing %q", err, tt.contains)
+			}
+		})
+	}
+}
+
+func TestParseBannerTrailingText(t *testing.T) {
+	// Legacy banners may end with notes; without a codepoint header first they are not an extended section
+	tests := [][]string{
+		{"Drawn by hand in 2019", "", "see README for details"},
+		{"U+D800 is not a character", "xx"},
+		{"", "", "~ the end ~"},
+	}
+
+	for _, trailing := range tests {
+		font, err := ascii.ParseBanner("legacy", extendedBannerLines(trailing...))
+		if err != nil {
+			t.Errorf("ParseBanner() with trailing %q unexpected error = %v", trailing, err)
+			continue
+		}
+		if len(font.Glyphs) != 95 {
+			t.Errorf("ParseBanner() with trailing %q loaded %d glyphs, want 95", trailing, len(font.Glyphs))
+		}
+	}
+}
+
+func TestExtendedGlyphsInEveryFeature(t *testing.T) {
+	font := extendedFont(t)
+
+	// Rendering
+	lines := ascii.RenderLines("aé€", font)
+	want := []string{" a /C=", "aaé C="}
+	if !equalSlices(lines, want) {
+		t.Fatalf("RenderLines() = %q, want %q", lines, want)
+	}
+
+	// Coloring only the é cells
+	canvas := ascii.Render("aé€", font, ascii.Options{})
+	colored, err := color.Colorize(canvas, "aé€", color.ColorConfig{Enabled: true, Color: "red", Substring: "é"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	if !strings.Contains(colored[1], "aa\033[38;2;255;0;0mé \033[0mC=") {
+		t.Errorf("Colorize() row = %q, want only é colored", colored[1])
+	}
+
+	// Justify renders words with extended glyphs instead of dropping them
+	justified := justify.RenderWithJustify("é a", font, ascii.Options{}, 40)
+	if !strings.Contains(justified[1], "é ") {
+		t.Errorf("RenderWithJustify() = %q, want the é glyph", justified)
+	}
+
+	// Reverse recognises the extended glyphs
+	text, err := reverse.RecogniseTextWithFont(strings.Join(lines, "\n"), font)
+	if err != nil || text != "aé€" {
+		t.Errorf("RecogniseTextWithFont() = %q, %v, want %q", text, err, "aé€")
+	}
+}
@@ -0,0 +1,137 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fallbackFonts returns a two row banner with only 'a' and a taller fallback font with 'b'
+func fallbackFonts() (*ascii.Font, *ascii.Font) {
+	banner := ascii.NewFont("banner", map[rune][]string{'a': {"aa", "aa"}})
+	other := ascii.NewFont("other", map[rune][]string{'b': {"b", "B", "b"}})
+	return banner, other
+}
+
+func TestRenderFallbackPolicies(t *testing.T) {
+	banner, other := fallbackFonts()
+
+	tests := []struct {
+		name     string
+		fallback ascii.Fallback
+		want     []string
+	}{
+		{name: "blank placeholder by default", want: []string{"aa        ", "aa        "}},
+		{name: "skip", fallback: ascii.Fallback{Mode: ascii.FallbackSkip}, want: []string{"aa", "aa"}},
+		{name: "replace", fallback: ascii.Fallback{Mode: ascii.FallbackReplace}, want: []string{"aa[?]", "aa   "}},
+		{name: "replace with custom character", fallback: ascii.Fallback{Mode: ascii.FallbackReplace, Replacement: '#'}, want: []string{"aa[#]", "aa   "}},
+		{name: "font chain is cut to the banner height", fallback: ascii.Fallback{Fonts: []*ascii.Font{other}, Mode: ascii.FallbackSkip}, want: []string{"aab", "aaB"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := ascii.Options{Fallback: tt.fallback}
+			lines := ascii.Render("ab", banner, opts).Lines()
+			if !equalSlices(lines, tt.want) {
+				t.Fatalf("Render() = %q, want %q", lines, tt.want)
+			}
+
+			// The measurer and justify share the policy, so widths match the output
+			width, _ := justify.MeasureText("ab", banner, opts)
+			if width != len(tt.want[0]) {
+				t.Errorf("MeasureText() width = %d, want %d", width, len(tt.want[0]))
+			}
+			words := justify.RenderWithJustify("ab", banner, opts, len(tt.want[0]))
+			if strings.TrimSpace(words[0]) != strings.TrimSpace(tt.want[0]) {
+				t.Errorf("RenderWithJustify() = %q, want %q", words, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplacementGlyphBox(t *testing.T) {
+	font := ascii.NewFont("tall", map[rune][]string{'a': {"a", "a", "a", "a"}})
+	opts := ascii.Options{Fallback: ascii.Fallback{Mode: ascii.FallbackReplace}}
+
+	lines := ascii.Render("x", font, opts).Lines()
+	want := []string{"+---+", "| ? |", "|   |", "+---+"}
+	if !equalSlices(lines, want) {
+		t.Errorf("Render() = %q, want %q", lines, want)
+	}
+
+	// The replacement stands for the input character, so it takes its color
+	colored, err := color.Colorize(ascii.Render("x", font, opts), "x", color.ColorConfig{Enabled: true, Color: "red"})
+	if err != nil || !strings.Contains(colored[1], "\033[") {
+		t.Errorf("Colorize() = %q, %v, want the replacement colored", colored, err)
+	}
+}
+
+func TestFallbackGlyphsPreparedOnce(t *testing.T) {
+	font := standardFont(t)
+	figlet, err := ascii.ParseFiglet("test", buildFigletFont())
+	if err != nil {
+		t.Fatalf("ParseFiglet() unexpected error = %v", err)
+	}
+
+	// Fallback fonts with hardblanks and replacement boxes reuse one glyph, however often the character appears
+	policies := map[string]ascii.Fallback{
+		"replace":       {Mode: ascii.FallbackReplace},
+		"fallback font": {Fonts: []*ascii.Font{figlet}},
+	}
+	for name, fallback := range policies {
+		opts := ascii.Options{Fallback: fallback}
+		few := testing.AllocsPerRun(5, func() { ascii.Render(strings.Repeat("☺Ä", 10), font, opts) })
+		many := testing.AllocsPerRun(5, func() { ascii.Render(strings.Repeat("☺Ä", 1000), font, opts) })
+		if many > few+5 {
+			t.Errorf("%s: rendering 2000 fallback characters took %.0f allocations, 20 took %.0f", name, many, few)
+		}
+	}
+}
+
+func TestCheckGlyphs(t *testing.T) {
+	banner, other := fallbackFonts()
+
+	err := ascii.CheckGlyphs("ab", banner, ascii.Options{Fallback: ascii.Fallback{Mode: ascii.FallbackError}})
+	if !errors.Is(err, ascii.ErrMissingGlyph) || !strings.Contains(err.Error(), "'b'") {
+		t.Errorf("CheckGlyphs() error = %v, want a missing glyph error for 'b'", err)
+	}
+
+	chain := ascii.Fallback{Fonts: []*ascii.Font{other}, Mode: ascii.FallbackError}
+	if err := ascii.CheckGlyphs("ab\na", banner, ascii.Options{Fallback: chain}); err != nil {
+		t.Errorf("CheckGlyphs() with a fallback font error = %v", err)
+	}
+
+	if err := ascii.CheckGlyphs("ab", banner, ascii.Options{}); err != nil {
+		t.Errorf("CheckGlyphs() with the blank policy error = %v", err)
+	}
+}
+
+func TestParseFallbackFlag(t *testing.T) {
+	fallback, remaining, err := ascii.ParseFallbackFlag([]string{"--fallback=shadow,standard,replace:#", "Hi"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(fallback.Fonts) != 2 || fallback.Fonts[0].Name != "shadow" || fallback.Fonts[1].Name != "standard" {
+		t.Errorf("Expected the shadow, standard chain, got %d fonts", len(fallback.Fonts))
+	}
+	if fallback.Mode != ascii.FallbackReplace || fallback.Replacement != '#' {
+		t.Errorf("Expected replace with '#', got mode %v replacement %q", fallback.Mode, fallback.Replacement)
+	}
+	if !equalSlices(remaining, []string{"Hi"}) {
+		t.Errorf("Unexpected remaining args %v", remaining)
+	}
+
+	invalid := []string{"--fallback", "--fallback=", "--fallback=skip,shadow", "--fallback=error:x", "--fallback=replace:ab", "--fallback=standard,,skip"}
+	for _, arg := range invalid {
+		if _, _, err := ascii.ParseFallbackFlag([]string{arg, "Hi"}); err != ascii.ErrInvalidFallbackFormat {
+			t.Errorf("ParseFallbackFlag(%q) error = %v, want ErrInvalidFallbackFormat", arg, err)
+		}
+	}
+
+	if _, _, err := ascii.ParseFallbackFlag([]string{"--fallback=nope"}); err == nil || !strings.Contains(err.Error(), "invalid fallback banner") {
+		t.Errorf("Expected an invalid fallback banner error, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildFigletFont returns the lines of a 2-row FIGlet font defining every required
+// character plus a code-tagged euro sign
+func buildFigletFont() []string {
+	lines := []string{
+		"flf2a$ 2 1 8 -1 2 0 0 1",
+		"Test font for unit tests",
+		"by the test suite",
+	}
+
+	required := []rune{}
+	for ch := rune(32); ch <= 126; ch++ {
+		required = append(required, ch)
+	}
+	required = append(required, 196, 214, 220, 228, 246, 252, 223)
+
+	for _, ch := range required {
+		switch ch {
+		case ' ':
+			lines = append(lines, "$$@", "$$@@")
+		case 'H':
+			lines = append(lines, "|_|@", "| |@@")
+		case 'i':
+			lines = append(lines, "o @", "| @@")
+		default:
+			lines = append(lines, "?? @", "?? @@")
+		}
+	}
+
+	lines = append(lines, "0x20AC  EURO SIGN", "C= #", "C= ##")
+	lines = append(lines, "-2  NEGATIVE CODE", "xx @", "xx @@")
+	return lines
+}
+
+func TestParseFiglet(t *testing.T) {
+	font, err := ascii.ParseFiglet("test", buildFigletFont())
+	if err != nil {
+		t.Fatalf("ParseFiglet() unexpected error = %v", err)
+	}
+
+	if font.Height != 2 || font.Baseline != 1 || font.MaxLength != 8 {
+		t.Errorf("header = height %d baseline %d max %d, want 2 1 8", font.Height, font.Baseline, font.MaxLength)
+	}
+	if font.Hardblank != '$' {
+		t.Errorf("Hardblank = %q, want '$'", font.Hardblank)
+	}
+	if font.OldLayout != -1 || font.FullLayout != 0 {
+		t.Errorf("layout = old %d full %d, want -1 0", font.OldLayout, font.FullLayout)
+	}
+	if !strings.Contains(font.Description, "Test font") {
+		t.Errorf("Description = %q, want the comment lines", font.Description)
+	}
+
+	// Endmarks are stripped and hardblanks become spaces
+	if got := font.Glyphs['H']; !equalSlices(got, []string{"|_|", "| |"}) {
+		t.Errorf("glyph 'H' = %q", got)
+	}
+	if got := font.Glyphs[' ']; !equalSlices(got, []string{"  ", "  "}) {
+		t.Errorf("glyph ' ' = %q", got)
+	}
+
+	// Deutsch and code-tagged characters are loaded, negative codes are skipped
+	if !font.Has(223) {
+		t.Error("expected Deutsch character ß to be loaded")
+	}
+	if got := font.Glyphs['€']; !equalSlices(got, []string{"C= ", "C= "}) {
+		t.Errorf("code-tagged glyph '€' = %q", got)
+	}
+	if len(font.Glyphs) != 95+7+1 {
+		t.Errorf("loaded %d glyphs, want %d", len(font.Glyphs), 95+7+1)
+	}
+}
+
+func TestParseFigletInvalidHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "missing signature", header: "flf2 2 1 8 -1 0"},
+		{name: "too few fields", header: "flf2a$ 2 1"},
+		{name: "non numeric height", header: "flf2a$ x 1 8 -1 0"},
+		{name: "zero height", header: "flf2a$ 0 1 8 -1 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ascii.ParseFiglet("bad", []string{tt.header}); err == nil {
+				t.Errorf("ParseFiglet(%q) expected error, got nil", tt.header)
+			}
+		})
+	}
+}
+
+func TestFigletPrintDirection(t *testing.T) {
+	lines := buildFigletFont()
+	lines[0] = "flf2a$ 2 1 8 -1 2 1 0 1" // print direction 1: right to left
+	font, err := ascii.ParseFiglet("rtl", lines)
+	if err != nil {
+		t.Fatalf("ParseFiglet() unexpected error = %v", err)
+	}
+	if !font.RightToLeft() {
+		t.Fatalf("RightToLeft() = false for print direction %d", font.PrintDirection)
+	}
+
+	// The first character is drawn rightmost, with or without cells
+	want := []string{"o |_|", "| | |"}
+	if got := ascii.RenderLines("Hi", font); !equalSlices(got, want) {
+		t.Errorf("RenderLines() = %q, want %q", got, want)
+	}
+	canvas := ascii.Render("Hi", font, ascii.Options{})
+	if got := canvas.Lines(); !equalSlices(got, want) {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+	if src := canvas.Rows[0][0].Src; src != 1 {
+		t.Errorf("leftmost cell comes from input offset %d, want 1", src)
+	}
+}
+
+func TestFigletFontAsBanner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tiny.flf")
+	content := strings.Join(buildFigletFont(), "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write font: %v", err)
+	}
+
+	if !ascii.IsValidBanner(path) {
+		t.Fatalf("IsValidBanner(%q) = false, want true", path)
+	}
+
+	font, err := ascii.LoadBannerFile(path)
+	if err != nil {
+		t.Fatalf("LoadBannerFile() unexpected error = %v", err)
+	}
+	if font.Name != "tiny" {
+		t.Errorf("font.Name = %q, want %q", font.Name, "tiny")
+	}
+
+	lines := ascii.RenderLines("Hi", font)
+	want := []string{"|_|o ", "| || "}
+	if !equalSlices(lines, want) {
+		t.Errorf("RenderLines() = %q, want %q", lines, want)
+	}
+
+	// The font path works as the banner argument of the color parser
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+	os.Args = []string{"cmd", "--color=red", "Hi", path}
+
+	input, banner, _, err := color.GetUserInputWithColor()
+	if err != nil {
+		t.Fatalf("GetUserInputWithColor() unexpected error = %v", err)
+	}
+	if input != "Hi" || banner != path {
+		t.Errorf("GetUserInputWithColor() = (%q, %q), want (%q, %q)", input, banner, "Hi", path)
+	}
+
+	if ascii.IsValidBanner(fmt.Sprintf("%s.missing.flf", path)) {
+		t.Error("IsValidBanner() = true for a font file that does not exist")
+	}
+}
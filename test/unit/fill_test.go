@@ -0,0 +1,103 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	"strings"
+	"testing"
+)
+
+func TestRenderFill(t *testing.T) {
+	font := ascii.NewFont("fill", map[rune][]string{
+		'a': {"/|", "- "},
+		'b': {"x ", "yy"},
+	})
+
+	tests := []struct {
+		name string
+		text string
+		fill ascii.Fill
+		want []string
+	}{
+		{name: "none", text: "ab", want: []string{"/|x ", "- yy"}},
+		{name: "solid", text: "ab", fill: ascii.Fill{Texture: []rune("#")}, want: []string{"### ", "# ##"}},
+		{name: "texture", text: "ab", fill: ascii.Fill{Texture: []rune("12")}, want: []string{"121 ", "2 12"}},
+		{name: "source", text: "ab", fill: ascii.Fill{Source: true}, want: []string{"aab ", "a bb"}},
+		{name: "texture restarts per line", text: "a\nb", fill: ascii.Fill{Texture: []rune("123")}, want: []string{"12", "3 ", "1 ", "23"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ascii.Render(tt.text, font, ascii.Options{Fill: tt.fill}).Lines()
+			if !equalSlices(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillKeepsLayoutAndColor(t *testing.T) {
+	font := standardFont(t)
+	opts := ascii.Options{Fill: ascii.Fill{Texture: []rune("@")}, Layout: ascii.LayoutSmush}
+
+	// Smushing sees the banner's characters, so the filled art has the same shape, and cells keep
+	// their sources, so colors still follow the input characters
+	plain := ascii.Render("Hello", font, ascii.Options{Layout: ascii.LayoutSmush})
+	canvas := ascii.Render("Hello", font, opts)
+	for i, row := range canvas.Rows {
+		for j, cell := range row {
+			want := plain.Rows[i][j]
+			if (cell.Ch == '@') != (want.Ch != ' ') || cell.Src != want.Src {
+				t.Fatalf("row %d column %d = %+v, want the ink and source of %+v", i, j, cell, want)
+			}
+		}
+	}
+
+	lines, err := color.Colorize(canvas, "Hello", color.ColorConfig{Enabled: true, Color: "red", Substring: "H"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	red, _ := color.ParseColor("red")
+	if !strings.HasPrefix(lines[2], red+"@") {
+		t.Errorf("line 2 = %q, want the filled H in red", lines[2])
+	}
+}
+
+func TestParseFillFlag(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		want  ascii.Fill
+		rest  []string
+		isErr bool
+	}{
+		{name: "character", args: []string{"--fill=█", "Hi"}, want: ascii.Fill{Texture: []rune("█")}, rest: []string{"Hi"}},
+		{name: "texture", args: []string{"Hi", "--fill=texture:<> "}, want: ascii.Fill{Texture: []rune("<> ")}, rest: []string{"Hi"}},
+		{name: "text", args: []string{"--fill=text"}, want: ascii.Fill{Source: true}, rest: []string{}},
+		{name: "absent", args: []string{"Hi"}, rest: []string{"Hi"}},
+		{name: "missing =", args: []string{"--fill", "#"}, isErr: true},
+		{name: "empty", args: []string{"--fill="}, isErr: true},
+		{name: "several characters", args: []string{"--fill=ab"}, isErr: true},
+		{name: "space", args: []string{"--fill= "}, isErr: true},
+		{name: "empty texture", args: []string{"--fill=texture:"}, isErr: true},
+		{name: "control character", args: []string{"--fill=texture:a\tb"}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := ascii.ParseFillFlag(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParseFillFlag() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFillFlag() unexpected error = %v", err)
+			}
+			if string(got.Texture) != string(tt.want.Texture) || got.Source != tt.want.Source || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParseFillFlag() = %+v, %q", got, rest)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadBuiltinBanners(t *testing.T) {
+	// Tests run from test/unit, where there is no banners directory
+	for _, name := range []string{"standard", "shadow", "thinkertoy"} {
+		font, err := ascii.LoadBannerFile(name)
+		if err != nil {
+			t.Fatalf("LoadBannerFile(%q) unexpected error = %v", name, err)
+		}
+		if font.Height != 8 || !font.Has('A') {
+			t.Errorf("LoadBannerFile(%q) = height %d, has 'A' %v", name, font.Height, font.Has('A'))
+		}
+	}
+}
+
+func TestDiskBannerOverridesBuiltin(t *testing.T) {
+	if err := os.MkdirAll("banners", 0755); err != nil {
+		t.Fatalf("Failed to create banners directory: %v", err)
+	}
+	defer os.RemoveAll("banners")
+
+	if err := os.WriteFile("banners/standard.txt", bannerFile([]string{"# height: 1"}, 1, nil), 0644); err != nil {
+		t.Fatalf("Failed to write banner: %v", err)
+	}
+
+	font, err := ascii.LoadBannerFile("standard")
+	if err != nil {
+		t.Fatalf("LoadBannerFile() unexpected error = %v", err)
+	}
+	if font.Height != 1 || len(font.Glyphs) != 95 {
+		t.Errorf("Expected the 1 row banner from disk, got height %d with %d glyphs", font.Height, len(font.Glyphs))
+	}
+}
+
+func TestFontSource(t *testing.T) {
+	source := ascii.FontSource{Name: "test", FS: fstest.MapFS{
+		"tiny.txt":     {Data: bannerFile([]string{"# height: 1"}, 1, nil)},
+		"block.tlf":    {Data: []byte("tlf2a$ 1 1 4 -1 0\n$@\n")},
+		"notes.md":     {Data: []byte("not a banner")},
+		"nested/x.txt": {Data: []byte(" \n")},
+	}}
+
+	if file, ok := source.Find("tiny"); !ok || file != "tiny.txt" {
+		t.Errorf("Find(tiny) = %q, %v", file, ok)
+	}
+	if file, ok := source.Find("block"); !ok || file != "block.tlf" {
+		t.Errorf("Find(block) = %q, %v", file, ok)
+	}
+	if _, ok := source.Find("notes"); ok {
+		t.Error("Find(notes) found a file that is not a banner")
+	}
+
+	if names := source.Names(); !equalSlices(names, []string{"block", "tiny"}) {
+		t.Errorf("Names() = %v, want [block tiny]", names)
+	}
+
+	font, err := source.Load("tiny", "tiny.txt")
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	if !font.Has('!') {
+		t.Error("Load() did not parse the banner glyphs")
+	}
+}
@@ -0,0 +1,106 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	justify "ascii-art/internal/ascii-justify"
+	reverse "ascii-art/internal/ascii-reverse"
+	"strings"
+	"testing"
+)
+
+// fourRowFont is a small banner with glyphs that are only 4 rows tall
+func fourRowFont() *ascii.Font {
+	return ascii.NewFont("four", map[rune][]string{
+		' ': {"  ", "  ", "  ", "  "},
+		'I': {"###", " # ", " # ", "###"},
+		'L': {"#  ", "#  ", "#  ", "###"},
+	})
+}
+
+func TestNewFontDerivesDimensions(t *testing.T) {
+	font := fourRowFont()
+
+	if font.Height != 4 {
+		t.Errorf("Height = %d, want 4", font.Height)
+	}
+	if font.Width('I') != 3 {
+		t.Errorf("Width('I') = %d, want 3", font.Width('I'))
+	}
+	if font.Width('x') != 0 {
+		t.Errorf("Width('x') = %d, want 0 for a missing glyph", font.Width('x'))
+	}
+
+	runes := font.Runes()
+	if string(runes) != " IL" {
+		t.Errorf("Runes() = %q, want %q", string(runes), " IL")
+	}
+}
+
+func TestRenderUsesFontHeight(t *testing.T) {
+	lines := ascii.RenderLines("IL\nLI", fourRowFont())
+	if len(lines) != 8 {
+		t.Fatalf("RenderLines() returned %d lines, want 8", len(lines))
+	}
+	if lines[3] != "######" {
+		t.Errorf("RenderLines() line 3 = %q, want %q", lines[3], "######")
+	}
+	if lines[4] != "#  ###" {
+		t.Errorf("RenderLines() line 4 = %q, want %q", lines[4], "#  ###")
+	}
+}
+
+func TestMeasureTextUsesFontHeight(t *testing.T) {
+	width, height := justify.MeasureText("IL\nI", fourRowFont(), ascii.Options{})
+	if width != 6 || height != 8 {
+		t.Errorf("MeasureText() = (%d, %d), want (6, 8)", width, height)
+	}
+}
+
+func TestRecogniseTextUsesFontHeight(t *testing.T) {
+	font := fourRowFont()
+	art := strings.Join(ascii.RenderLines("LI L", font), "\n")
+
+	chunks, err := reverse.ParseAsciiArt(art, font.Height)
+	if err != nil {
+		t.Fatalf("ParseAsciiArt() unexpected error = %v", err)
+	}
+
+	got, err := reverse.RecogniseText(chunks, font)
+	if err != nil {
+		t.Fatalf("RecogniseText() unexpected error = %v", err)
+	}
+	if got != "LI L" {
+		t.Errorf("RecogniseText() = %q, want %q", got, "LI L")
+	}
+}
+
+func TestJustifyAlignUsesFontHeight(t *testing.T) {
+	font := fourRowFont()
+	lines := ascii.RenderLines("I  L\nL  I", font)
+
+	result := justify.JustifyAlign(lines, 40, font.Height)
+	if len(result) != len(lines) {
+		t.Fatalf("JustifyAlign() returned %d lines, want %d", len(result), len(lines))
+	}
+	if !strings.HasSuffix(strings.TrimRight(result[3], " "), "###") {
+		t.Errorf("JustifyAlign() line 3 = %q, expected the glyph rows to stay grouped", result[3])
+	}
+}
+
+func TestSetGlyphUpdatesRendering(t *testing.T) {
+	font := ascii.NewFont("table", map[rune][]string{
+		'a': {"a", "A"},
+		'é': {"e", "E"},
+		'█': {"#", "#"},
+	})
+	if got := ascii.RenderLines("aé█", font); !equalSlices(got, []string{"ae#", "AE#"}) {
+		t.Fatalf("RenderLines() = %q", got)
+	}
+
+	// Replacing glyphs after rendering, inside and outside the dense table range
+	font.SetGlyph('a', []string{"xx", "x"})
+	font.SetGlyph('█', []string{"@", "@"})
+	if got := ascii.RenderLines("aé█", font); !equalSlices(got, []string{"xxe@", "x E@"}) {
+		t.Errorf("RenderLines() after SetGlyph = %q", got)
+	}
+}
@@ -0,0 +1,115 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	input "ascii-art/internal/ascii-input"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseInputFlag(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		expectedFile string
+		expectedArgs []string
+		expectError  bool
+	}{
+		{name: "File", args: []string{"--input=notes.txt", "shadow"}, expectedFile: "notes.txt", expectedArgs: []string{"shadow"}},
+		{name: "Stdin", args: []string{"--color=red", "--input=-"}, expectedFile: input.Stdin, expectedArgs: []string{"--color=red"}},
+		{name: "No input flag", args: []string{"Hello"}, expectedFile: "", expectedArgs: []string{"Hello"}},
+		{name: "Malformed flag - missing =", args: []string{"--input", "notes.txt"}, expectError: true},
+		{name: "Empty filename", args: []string{"--input="}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, args, err := input.ParseInputFlag(tt.args)
+			if tt.expectError {
+				if !errors.Is(err, input.ErrInvalidInputFormat) {
+					t.Errorf("ParseInputFlag() error = %v, want ErrInvalidInputFormat", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseInputFlag() unexpected error = %v", err)
+			}
+			if file != tt.expectedFile || !equalSlices(args, tt.expectedArgs) {
+				t.Errorf("ParseInputFlag() = %q, %q, want %q, %q", file, args, tt.expectedFile, tt.expectedArgs)
+			}
+		})
+	}
+}
+
+func TestHasText(t *testing.T) {
+	if input.HasText([]string{"--color=red"}) {
+		t.Error("HasText() = true for a lone color flag")
+	}
+	if !input.HasText([]string{"--color=red", "hello"}) {
+		t.Error("HasText() = false with text after the color flag")
+	}
+	if input.HasText([]string{"shadow"}) || input.HasText([]string{"--color=red", "shadow"}) {
+		t.Error("HasText() = true for a lone banner name")
+	}
+	if !input.HasText([]string{"shadow", "shadow"}) {
+		t.Error("HasText() = false for text followed by a banner")
+	}
+}
+
+func TestParseBannerFlag(t *testing.T) {
+	banner, args, err := ascii.ParseBannerFlag([]string{"--banner=shadow", "Hello"})
+	if err != nil {
+		t.Fatalf("ParseBannerFlag() unexpected error = %v", err)
+	}
+	if banner != "shadow" || !equalSlices(args, []string{"Hello"}) {
+		t.Errorf("ParseBannerFlag() = %q, %q", banner, args)
+	}
+
+	if _, _, err := ascii.ParseBannerFlag([]string{"--banner", "shadow"}); !errors.Is(err, ascii.ErrInvalidBannerFormat) {
+		t.Errorf("ParseBannerFlag() without = error = %v", err)
+	}
+	if _, _, err := ascii.ParseBannerFlag([]string{"--banner=nope"}); !errors.Is(err, ascii.ErrInvalidBanner) {
+		t.Errorf("ParseBannerFlag() unknown banner error = %v", err)
+	}
+	if _, args, _ := ascii.ParseBannerFlag([]string{"--banner-dir=x"}); len(args) != 1 {
+		t.Error("ParseBannerFlag() consumed --banner-dir")
+	}
+}
+
+func TestGetStreamArgsWithColor(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		wantBanner    string
+		wantColor     color.ColorConfig
+		errorContains string
+	}{
+		{name: "No arguments", args: nil, wantBanner: "standard"},
+		{name: "Banner only", args: []string{"shadow"}, wantBanner: "shadow"},
+		{name: "Color", args: []string{"--color=red"}, wantBanner: "standard", wantColor: color.ColorConfig{Enabled: true, Color: "red"}},
+		{name: "Color, substring and banner", args: []string{"--color=red", "ok", "shadow"}, wantBanner: "shadow", wantColor: color.ColorConfig{Enabled: true, Color: "red", Substring: "ok"}},
+		{name: "Text is refused", args: []string{"hello", "shadow"}, errorContains: "text cannot be given"},
+		{name: "Unknown banner", args: []string{"hello"}, errorContains: "invalid banner style"},
+		{name: "Invalid color", args: []string{"--color=nocolor"}, errorContains: "nocolor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banner, colorConfig, err := color.GetStreamArgsWithColor(tt.args)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("GetStreamArgsWithColor() error = %v, want it to contain %q", err, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetStreamArgsWithColor() unexpected error = %v", err)
+			}
+			if banner != tt.wantBanner || colorConfig != tt.wantColor {
+				t.Errorf("GetStreamArgsWithColor() = %q, %+v, want %q, %+v", banner, colorConfig, tt.wantBanner, tt.wantColor)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	"strings"
+	"testing"
+)
+
+func TestParseLayoutFlag(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		expectedLayout ascii.Layout
+		expectedArgs   []string
+		expectError    bool
+		errorContains  string
+	}{
+		{
+			name:           "Valid smush layout",
+			args:           []string{"--layout=smush", "Hello", "shadow"},
+			expectedLayout: ascii.LayoutSmush,
+			expectedArgs:   []string{"Hello", "shadow"},
+		},
+		{
+			name:           "Valid fit layout among other flags",
+			args:           []string{"--color=red", "--layout=fit", "Hello"},
+			expectedLayout: ascii.LayoutFit,
+			expectedArgs:   []string{"--color=red", "Hello"},
+		},
+		{
+			name:           "Valid full layout",
+			args:           []string{"--layout=full", "Hello"},
+			expectedLayout: ascii.LayoutFull,
+			expectedArgs:   []string{"Hello"},
+		},
+		{
+			name:           "No layout flag - font default",
+			args:           []string{"Hello", "standard"},
+			expectedLayout: ascii.LayoutDefault,
+			expectedArgs:   []string{"Hello", "standard"},
+		},
+		{
+			name:          "Invalid layout mode",
+			args:          []string{"--layout=squash", "Hello"},
+			expectError:   true,
+			errorContains: "invalid layout mode",
+		},
+		{
+			name:          "Malformed flag - missing =",
+			args:          []string{"--layout", "smush", "Hello"},
+			expectError:   true,
+			errorContains: "invalid --layout flag format",
+		},
+		{
+			name:          "Empty layout mode",
+			args:          []string{"--layout=", "Hello"},
+			expectError:   true,
+			errorContains: "invalid --layout flag format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, remaining, err := ascii.ParseLayoutFlag(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected error containing %q, got nil", tt.errorContains)
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("Expected error containing %q, got %q", tt.errorContains, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if layout != tt.expectedLayout {
+				t.Errorf("Expected layout %v, got %v", tt.expectedLayout, layout)
+			}
+			if !equalSlices(remaining, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, remaining)
+			}
+		})
+	}
+}
+
+func TestParseVLayoutFlag(t *testing.T) {
+	layout, remaining, err := ascii.ParseVLayoutFlag([]string{"--layout=smush", "--vlayout=fit", "Hello"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if layout != ascii.LayoutFit {
+		t.Errorf("Expected fit, got %v", layout)
+	}
+	if !equalSlices(remaining, []string{"--layout=smush", "Hello"}) {
+		t.Errorf("Unexpected remaining args %v", remaining)
+	}
+
+	if _, _, err := ascii.ParseVLayoutFlag([]string{"--vlayout", "fit"}); err != ascii.ErrInvalidVLayoutFormat {
+		t.Errorf("Expected ErrInvalidVLayoutFormat, got %v", err)
+	}
+	if _, _, err := ascii.ParseVLayoutFlag([]string{"--vlayout=squash"}); err == nil || !strings.Contains(err.Error(), "invalid vertical layout mode") {
+		t.Errorf("Expected invalid vertical layout mode error, got %v", err)
+	}
+}
@@ -42,7 +42,7 @@ func TestGetUserInput(t *testing.T) {
 		{
 			name:          "Invalid banner style",
 			args:          []string{"cmd", "Hey", "unknown"}, // Invalid banner name
-			expectedError: ascii.ErrInvalidBanner,
+			expectedError: ascii.WrapInvalidBannerError("unknown"),
 		},
 		{
 			name:           "Escaped newline in input",
@@ -50,6 +50,12 @@ func TestGetUserInput(t *testing.T) {
 			expectedInput:  "Hello\nWorld",
 			expectedBanner: "standard",
 		},
+		{
+			name:           "Escaped tab and backslash in input",
+			args:           []string{"cmd", "a\\tb\\\\c"}, // \t expands to the next tab stop, \\ is one backslash
+			expectedInput:  "a   b\\c",
+			expectedBanner: "standard",
+		},
 		{
 			name:           "Input with leading and trailing spaces",
 			args:           []string{"cmd", "   Hello   "}, // Spaces should be trimmed
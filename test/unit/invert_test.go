@@ -0,0 +1,124 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	"strings"
+	"testing"
+)
+
+func invertFont() *ascii.Font {
+	return ascii.NewFont("invert", map[rune][]string{
+		'a': {"/\\", "  "},
+		'b': {"#", "#"},
+		' ': {" ", " "},
+	})
+}
+
+func TestRenderInvert(t *testing.T) {
+	font := invertFont()
+
+	tests := []struct {
+		name   string
+		text   string
+		invert rune
+		want   []string
+	}{
+		{name: "none", text: "a", want: []string{"/\\", "  "}},
+		{name: "inverted", text: "ab", invert: '#', want: []string{"   ", "## "}},
+		{name: "box is the widest row", text: "b\naa", invert: '.', want: []string{" ...", " ...", "    ", "...."}},
+		{name: "leading blank columns stay outside", text: " b", invert: '.', want: []string{"  ", "  "}},
+		{name: "no ink", text: " ", invert: '.', want: []string{" ", " "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ascii.Render(tt.text, font, ascii.Options{Invert: tt.invert}).Lines()
+			if !equalSlices(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvertColorsBackground(t *testing.T) {
+	font := invertFont()
+	canvas := ascii.Render("ba", font, ascii.Options{Invert: '#'})
+	red, _ := color.ParseColor("red")
+	background := strings.Replace(red, "[38;", "[48;", 1)
+	reset := color.ResetColor()
+
+	// The ink is left bare and the background of the colored characters becomes colored blocks
+	lines, err := color.Colorize(canvas, "ba", color.ColorConfig{Enabled: true, Color: "red", Substring: "a", Invert: true})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	want := []string{"   ", " " + background + "  " + reset}
+	if !equalSlices(lines, want) {
+		t.Errorf("Colorize() = %q, want %q", lines, want)
+	}
+}
+
+func TestInvertStreamsWholeBlock(t *testing.T) {
+	font := invertFont()
+	opts := ascii.Options{Invert: '.'}
+
+	stream := ascii.NewStream(font, opts)
+	for _, line := range []string{"b", "aa"} {
+		if rows := stream.Add(line); rows.Height() != 0 {
+			t.Errorf("Add(%q) returned %d rows before the block was complete", line, rows.Height())
+		}
+	}
+
+	// The first line is inverted as wide as the second
+	got := stream.Flush().Lines()
+	if want := ascii.Render("b\naa", font, opts).Lines(); !equalSlices(got, want) {
+		t.Errorf("Flush() = %q, want %q", got, want)
+	}
+}
+
+func TestJustifyInvertsWholeBlock(t *testing.T) {
+	font := standardFont(t)
+
+	plain := justify.RenderWithJustify("ab cd", font, ascii.Options{}, 80)
+	got := justify.RenderWithJustify("ab cd", font, ascii.Options{Invert: '#'}, 80)
+	if want := ascii.InvertLines(plain, '#'); !equalSlices(got, want) {
+		t.Errorf("RenderWithJustify() = %q, want %q", got, want)
+	}
+}
+
+func TestParseInvertFlag(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		want  rune
+		rest  []string
+		isErr bool
+	}{
+		{name: "bare", args: []string{"--invert", "Hi"}, want: ascii.DefaultInvertChar, rest: []string{"Hi"}},
+		{name: "character", args: []string{"Hi", "--invert=░"}, want: '░', rest: []string{"Hi"}},
+		{name: "absent", args: []string{"Hi"}, rest: []string{"Hi"}},
+		{name: "empty", args: []string{"--invert="}, isErr: true},
+		{name: "several characters", args: []string{"--invert=ab"}, isErr: true},
+		{name: "space", args: []string{"--invert= "}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := ascii.ParseInvertFlag(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParseInvertFlag() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseInvertFlag() unexpected error = %v", err)
+			}
+			if got != tt.want || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParseInvertFlag() = %q, %q", got, rest)
+			}
+		})
+	}
+}
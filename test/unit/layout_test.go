@@ -0,0 +1,188 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	"testing"
+)
+
+// layoutFont is a one row font for checking how glyphs are placed
+func layoutFont() *ascii.Font {
+	return ascii.NewFont("layout", map[rune][]string{
+		'a':  {"ab "},
+		'b':  {" ba"},
+		'_':  {" __"},
+		'|':  {"|| "},
+		'/':  {" //"},
+		'\\': {`\\ `},
+		'[':  {" [["},
+		']':  {"]] "},
+		'>':  {" >>"},
+		'<':  {"<< "},
+		'$':  {" $$"},
+		'h':  {"$$ "},
+	})
+}
+
+func TestRenderLayoutModes(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		layout ascii.Layout
+		want   string
+	}{
+		{name: "full width concatenates glyphs", input: "ab", layout: ascii.LayoutFull, want: "ab  ba"},
+		{name: "fitting slides glyphs until they touch", input: "ab", layout: ascii.LayoutFit, want: "abba"},
+		{name: "equal character rule", input: "ab", layout: ascii.LayoutSmush, want: "aba"},
+		{name: "underscore rule", input: "_|", layout: ascii.LayoutSmush, want: " _|| "},
+		{name: "hierarchy rule", input: "/[", layout: ascii.LayoutSmush, want: " /[["},
+		{name: "opposite pair rule", input: "[]", layout: ascii.LayoutSmush, want: " [|] "},
+		{name: "big X slash rule", input: `/\`, layout: ascii.LayoutSmush, want: ` /|\ `},
+		{name: "big X angle rule", input: "><", layout: ascii.LayoutSmush, want: " >X< "},
+		{name: "default uses font header", input: "ab", layout: ascii.LayoutDefault, want: "ab  ba"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := ascii.Render(tt.input, layoutFont(), ascii.Options{Layout: tt.layout}).Lines()
+			if len(lines) != 1 || lines[0] != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.input, lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderHardblankRule(t *testing.T) {
+	font := ascii.NewFont("hard", nil)
+	font.Hardblank = '$'
+	font.SetGlyph('$', []string{" $$"})
+	font.SetGlyph('h', []string{"$$ "})
+
+	// Without the rule hardblanks never merge, so the glyphs only fit
+	font.FullLayout = ascii.LayoutSmushBit | ascii.SmushEqual
+	if got := ascii.RenderLines("$h", font); got[0] != "      " {
+		t.Errorf("RenderLines() without hardblank rule = %q, want %q", got[0], "      ")
+	}
+
+	// With the rule two hardblanks merge, and hardblanks are drawn as spaces
+	font.FullLayout = ascii.LayoutSmushBit | ascii.SmushHardblank
+	if got := ascii.RenderLines("$h", font); got[0] != "     " {
+		t.Errorf("RenderLines() with hardblank rule = %q, want %q", got[0], "     ")
+	}
+}
+
+func TestFontHeaderLayout(t *testing.T) {
+	tests := []struct {
+		name      string
+		old       int
+		wantMode  ascii.Layout
+		wantRules int
+	}{
+		{name: "old layout -1 is full width", old: -1, wantMode: ascii.LayoutFull},
+		{name: "old layout 0 is fitting", old: 0, wantMode: ascii.LayoutFit},
+		{name: "positive old layout is smushing", old: 15, wantMode: ascii.LayoutSmush, wantRules: 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := buildFigletFont()
+			lines[0] = "flf2a$ 2 1 8 " + itoa(tt.old) + " 2"
+
+			font, err := ascii.ParseFiglet("test", lines)
+			if err != nil {
+				t.Fatalf("ParseFiglet() unexpected error = %v", err)
+			}
+
+			mode, rules := font.HorizontalLayout()
+			if mode != tt.wantMode || rules != tt.wantRules {
+				t.Errorf("HorizontalLayout() = (%v, %d), want (%v, %d)", mode, rules, tt.wantMode, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestBannerHeaderLayout(t *testing.T) {
+	font, err := ascii.ParseBanner("test", append([]string{"# layout: smush", ""}, bannerLines(1, nil)...))
+	if err != nil {
+		t.Fatalf("ParseBanner() unexpected error = %v", err)
+	}
+
+	mode, rules := font.HorizontalLayout()
+	if mode != ascii.LayoutSmush || rules != ascii.SmushAllRules {
+		t.Errorf("HorizontalLayout() = (%v, %d), want smushing with all rules", mode, rules)
+	}
+}
+
+func TestMeasureTextReportsSmushedWidth(t *testing.T) {
+	font := layoutFont()
+
+	width, _ := justify.MeasureText("ab", font, ascii.Options{Layout: ascii.LayoutSmush})
+	if width != 3 {
+		t.Errorf("MeasureText() smushed width = %d, want 3", width)
+	}
+
+	width, _ = justify.MeasureText("ab", font, ascii.Options{Layout: ascii.LayoutFull})
+	if width != 6 {
+		t.Errorf("MeasureText() full width = %d, want 6", width)
+	}
+}
+
+func TestColorizeSmushedCanvas(t *testing.T) {
+	canvas := ascii.Render("ab", layoutFont(), ascii.Options{Layout: ascii.LayoutSmush})
+	config := color.ColorConfig{Enabled: true, Color: "red", Substring: "b"}
+
+	lines, err := color.Colorize(canvas, "ab", config)
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+
+	// The merged column belongs to 'b', which drew it last
+	red, _ := color.ParseColor("red")
+	want := "a" + red + "ba" + color.ResetColor()
+	if lines[0] != want {
+		t.Errorf("Colorize() = %q, want %q", lines[0], want)
+	}
+}
+
+// TestRenderPlainMatchesRender checks that rows written straight from the glyph table match the rendered cells
+func TestRenderPlainMatchesRender(t *testing.T) {
+	figlet, err := ascii.ParseFiglet("test", buildFigletFont())
+	if err != nil {
+		t.Fatalf("ParseFiglet() unexpected error = %v", err)
+	}
+	fonts := []*ascii.Font{standardFont(t), figlet}
+	inputs := []string{"Hello, World!", "", "a\n\nb", "H i\n", "caf\u00e9 \u263a", "wrapping a long line of words at a narrow width"}
+	options := []ascii.Options{
+		{},
+		{Layout: ascii.LayoutFull},
+		{Layout: ascii.LayoutFull, ScaleX: 2, ScaleY: 3},
+		{Layout: ascii.LayoutFull, Width: 40, Hyphenate: true},
+		{Layout: ascii.LayoutFull, Fallback: ascii.Fallback{Mode: ascii.FallbackSkip}},
+		{Layout: ascii.LayoutFull, Fallback: ascii.Fallback{Mode: ascii.FallbackReplace}, Width: 30},
+		{Layout: ascii.LayoutFull, LetterSpacing: 1, LineSpacing: 1},
+		{Layout: ascii.LayoutFull, Border: ascii.Border{Chars: ascii.BorderNames["ascii"]}},
+	}
+
+	for _, font := range fonts {
+		for _, input := range inputs {
+			for i, opts := range options {
+				want := ascii.Render(input, font, opts).Lines()
+				if got := ascii.RenderPlain(input, font, opts); !equalSlices(got, want) {
+					t.Errorf("%s options %d: RenderPlain(%q) = %q, want %q", font.Name, i, input, got, want)
+				}
+			}
+		}
+	}
+}
+
+// itoa formats small integers for building font headers
+func itoa(n int) string {
+	if n < 0 {
+		return "-" + itoa(-n)
+	}
+	if n < 10 {
+		return string(rune('0' + n))
+	}
+	return itoa(n/10) + string(rune('0'+n%10))
+}
@@ -2,8 +2,10 @@ package unit_test
 
 import (
 	"ascii-art/internal/ascii"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -12,16 +14,8 @@ func TestLoadBannerFile(t *testing.T) {
 	tempDir := t.TempDir() // Creates a temporary directory for the test
 	filepath := filepath.Join(tempDir, "test_banner.txt")
 
-	content := `line1
-line2
-line3
-line4
-line5
-line6
-line7
-line8
-
-` // <- 1 char block with 8 lines + 1 separator (represents rune 32: ' ')
+	content := strings.Repeat(" \n", 8) + "\n" // <- 1 char block with 8 lines + 1 separator (represents rune 32: ' ')
+	content += strings.Join(restOfBanner('!', 8), "\n")
 
 	// Write the file
 	if err := os.WriteFile(filepath, []byte(content), 0644); err != nil {
@@ -45,12 +39,16 @@ line8
 	}
 
 	// Check the result
-	if len(result) != 1 {
-		t.Errorf("Expected 1 character in banner map, got %d", len(result))
+	if len(result.Glyphs) != 95 {
+		t.Errorf("Expected 95 characters in banner font, got %d", len(result.Glyphs))
+	}
+
+	if result.Height != 8 {
+		t.Errorf("Expected font height 8, got %d", result.Height)
 	}
 
 	r := rune(32) // ASCII 32 is space
-	charLines, ok := result[r]
+	charLines, ok := result.Glyphs[r]
 	if !ok {
 		t.Errorf("Expected rune %q to be in banner map", r)
 	}
@@ -65,3 +63,92 @@ func TestLoadBannerFile_FileNotFound(t *testing.T) {
 		t.Fatalf("Expected error for non-existent banner file, got nil")
 	}
 }
+
+func TestParseBannerHeaderAndHeight(t *testing.T) {
+	lines := []string{
+		"# name: tiny",
+		"# author: Jane Doe",
+		"# description: four row test banner",
+		"",
+		"  ",
+		"  ",
+		"  ",
+		"  ",
+		"",
+		"| ",
+		"| ",
+		"  ",
+		". ",
+		"",
+	}
+	lines = append(lines, restOfBanner('"', 4)...)
+
+	font, err := ascii.ParseBanner("fallback", lines)
+	if err != nil {
+		t.Fatalf("ParseBanner returned unexpected error: %v", err)
+	}
+
+	if font.Name != "tiny" || font.Author != "Jane Doe" || font.Description != "four row test banner" {
+		t.Errorf("Unexpected metadata: name=%q author=%q description=%q", font.Name, font.Author, font.Description)
+	}
+	if font.Height != 4 {
+		t.Errorf("Expected height 4, got %d", font.Height)
+	}
+	if !font.Has(' ') || !font.Has('!') {
+		t.Fatalf("Expected glyphs for ' ' and '!', got %v", font.Runes())
+	}
+	if font.Width('!') != 2 {
+		t.Errorf("Expected width 2 for '!', got %d", font.Width('!'))
+	}
+}
+
+func TestParseBannerExplicitHeight(t *testing.T) {
+	font, err := ascii.ParseBanner("blank", append([]string{"# height: 2", "   ", "   ", ""}, restOfBanner('!', 2)...))
+	if err != nil {
+		t.Fatalf("ParseBanner returned unexpected error: %v", err)
+	}
+	if font.Height != 2 || !font.Has(' ') {
+		t.Errorf("Expected a 2 row font with a space glyph, got height %d and %v", font.Height, font.Runes())
+	}
+
+	if _, err := ascii.ParseBanner("bad", []string{"# height: zero", "   ", "   ", ""}); err == nil {
+		t.Error("Expected error for invalid height header, got nil")
+	}
+}
+
+func TestParseBannerMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+	}{
+		{name: "text file", lines: []string{"Shopping list", "milk", "", "eggs", ""}},
+		{name: "first glyph has ink", lines: append([]string{"!!", "!!", ""}, restOfBanner('!', 2)...)},
+		{name: "missing glyphs", lines: []string{"  ", "  ", "", "| ", ". ", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ascii.ParseBanner("notes", tt.lines)
+			if !errors.Is(err, ascii.ErrMalformedBanner) || err.Error() != "banner notes: malformed" {
+				t.Errorf("ParseBanner() error = %v, want banner notes: malformed", err)
+			}
+		})
+	}
+
+	// Reverse templates may hold only part of a banner
+	if _, err := ascii.ParsePartialBanner("notes", []string{"  ", "  ", "", "| ", ". ", ""}); err != nil {
+		t.Errorf("ParsePartialBanner() unexpected error = %v", err)
+	}
+}
+
+// restOfBanner returns the glyph blocks from from up to '~', each height rows of "?" and a separator
+func restOfBanner(from rune, height int) []string {
+	var lines []string
+	for ch := from; ch <= '~'; ch++ {
+		for range height {
+			lines = append(lines, "?")
+		}
+		lines = append(lines, "")
+	}
+	return lines
+}
@@ -130,6 +130,16 @@ func TestGetLineWidth(t *testing.T) {
 			line:     " _   _      _ _       ",
 			expected: 22,
 		},
+		{
+			name:     "Multi-byte block characters",
+			line:     "█▀█",
+			expected: 3,
+		},
+		{
+			name:     "Color codes take no space",
+			line:     "\033[31m█ █\033[0m▄",
+			expected: 4,
+		},
 	}
 
 	for _, tt := range tests {
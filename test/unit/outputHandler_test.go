@@ -3,12 +3,13 @@ package unit
 import (
 	output "ascii-art/internal/ascii-output"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 )
 
-func TestCaptureStdout(t *testing.T) {
+func TestCaptureOutput(t *testing.T) {
 	tests := []struct {
 		name       string
 		renderFunc output.RenderFunc
@@ -16,33 +17,33 @@ func TestCaptureStdout(t *testing.T) {
 	}{
 		{
 			name: "capture simple output",
-			renderFunc: func() {
-				fmt.Print("Hello, World!")
+			renderFunc: func(w io.Writer) {
+				fmt.Fprint(w, "Hello, World!")
 			},
 			want: "Hello, World!",
 		},
 		{
 			name: "capture multiline output",
-			renderFunc: func() {
-				fmt.Println("Line 1")
-				fmt.Println("Line 2")
-				fmt.Println("Line 3")
+			renderFunc: func(w io.Writer) {
+				fmt.Fprintln(w, "Line 1")
+				fmt.Fprintln(w, "Line 2")
+				fmt.Fprintln(w, "Line 3")
 			},
 			want: "Line 1\nLine 2\nLine 3\n",
 		},
 		{
 			name: "capture empty output",
-			renderFunc: func() {
+			renderFunc: func(w io.Writer) {
 				// Do nothing
 			},
 			want: "",
 		},
 		{
 			name: "capture ASCII art output",
-			renderFunc: func() {
-				fmt.Print(" _    _          _   _          \n")
-				fmt.Print("| |  | |        | | | |         \n")
-				fmt.Print("| |__| |   ___  | | | |   ___   \n")
+			renderFunc: func(w io.Writer) {
+				fmt.Fprint(w, " _    _          _   _          \n")
+				fmt.Fprint(w, "| |  | |        | | | |         \n")
+				fmt.Fprint(w, "| |__| |   ___  | | | |   ___   \n")
 			},
 			want: " _    _          _   _          \n| |  | |        | | | |         \n| |__| |   ___  | | | |   ___   \n",
 		},
@@ -50,14 +51,10 @@ func TestCaptureStdout(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := output.CaptureStdout(tt.renderFunc)
-			if err != nil {
-				t.Errorf("CaptureStdout() unexpected error = %v", err)
-				return
-			}
+			got := output.CaptureOutput(tt.renderFunc)
 
 			if got != tt.want {
-				t.Errorf("CaptureStdout() = %q, want %q", got, tt.want)
+				t.Errorf("CaptureOutput() = %q, want %q", got, tt.want)
 			}
 		})
 	}
@@ -77,8 +74,8 @@ func TestHandleOutput(t *testing.T) {
 		{
 			name:       "output to stdout (no file)",
 			outputFile: "",
-			renderFunc: func() {
-				fmt.Print("Direct to stdout")
+			renderFunc: func(w io.Writer) {
+				fmt.Fprint(w, "Direct to stdout")
 			},
 			wantOutput: "Direct to stdout",
 			wantErr:    false,
@@ -87,8 +84,8 @@ func TestHandleOutput(t *testing.T) {
 		{
 			name:       "output to file",
 			outputFile: filepath.Join(tempDir, "output1.txt"),
-			renderFunc: func() {
-				fmt.Print("Hello to file")
+			renderFunc: func(w io.Writer) {
+				fmt.Fprint(w, "Hello to file")
 			},
 			wantOutput: "Hello to file",
 			wantErr:    false,
@@ -97,9 +94,9 @@ func TestHandleOutput(t *testing.T) {
 		{
 			name:       "output multiline to file",
 			outputFile: filepath.Join(tempDir, "output2.txt"),
-			renderFunc: func() {
-				fmt.Println("Line 1")
-				fmt.Println("Line 2")
+			renderFunc: func(w io.Writer) {
+				fmt.Fprintln(w, "Line 1")
+				fmt.Fprintln(w, "Line 2")
 			},
 			wantOutput: "Line 1\nLine 2\n",
 			wantErr:    false,
@@ -108,9 +105,9 @@ func TestHandleOutput(t *testing.T) {
 		{
 			name:       "output ASCII art to file",
 			outputFile: filepath.Join(tempDir, "banner.txt"),
-			renderFunc: func() {
-				fmt.Print(" _              _   _          \n")
-				fmt.Print("| |            | | | |         \n")
+			renderFunc: func(w io.Writer) {
+				fmt.Fprint(w, " _              _   _          \n")
+				fmt.Fprint(w, "| |            | | | |         \n")
 			},
 			wantOutput: " _              _   _          \n| |            | | | |         \n",
 			wantErr:    false,
@@ -157,8 +154,8 @@ func TestHandleOutput(t *testing.T) {
 
 func TestHandleOutputInvalidPath(t *testing.T) {
 	// Test with invalid file path
-	renderFunc := func() {
-		fmt.Print("test")
+	renderFunc := func(w io.Writer) {
+		fmt.Fprint(w, "test")
 	}
 
 	err := output.HandleOutput("/invalid/path/file.txt", renderFunc)
@@ -167,21 +164,23 @@ func TestHandleOutputInvalidPath(t *testing.T) {
 	}
 }
 
-func TestCaptureStdoutRestoresStdout(t *testing.T) {
-	// Verify that stdout is properly restored after capture
+func TestCaptureOutputLeavesStdoutUntouched(t *testing.T) {
+	// Verify that capturing never swaps out the process-wide stdout
 	originalStdout := os.Stdout
 
-	renderFunc := func() {
-		fmt.Print("test output")
+	renderFunc := func(w io.Writer) {
+		if os.Stdout != originalStdout {
+			t.Error("CaptureOutput() redirected os.Stdout during render")
+		}
+		fmt.Fprint(w, "test output")
 	}
 
-	_, err := output.CaptureStdout(renderFunc)
-	if err != nil {
-		t.Errorf("CaptureStdout() error = %v", err)
+	got := output.CaptureOutput(renderFunc)
+	if got != "test output" {
+		t.Errorf("CaptureOutput() = %q, want %q", got, "test output")
 	}
 
-	// Check that stdout was restored
 	if os.Stdout != originalStdout {
-		t.Error("CaptureStdout() did not restore os.Stdout")
+		t.Error("CaptureOutput() changed os.Stdout")
 	}
 }
@@ -0,0 +1,163 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	input "ascii-art/internal/ascii-input"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// parallelText returns a document whose lines vary in length, including empty and wrapped lines
+func parallelText(lines int) string {
+	words := []string{"alpha", "beta", "ok", "fox", "Jumps", "42", "!?", "naïve", "smush_|"}
+	text := make([]string, lines)
+	for i := range text {
+		var line []string
+		for j := 0; j < (i*7)%11; j++ {
+			line = append(line, words[(i+j*3)%len(words)])
+		}
+		text[i] = strings.Join(line, " ")
+	}
+	return strings.Join(text, "\n")
+}
+
+func TestRenderParallelMatchesSerial(t *testing.T) {
+	font := standardFont(t)
+	text := parallelText(500)
+
+	tests := []struct {
+		name string
+		opts ascii.Options
+	}{
+		{name: "full layout"},
+		{name: "smushing both ways", opts: ascii.Options{Layout: ascii.LayoutSmush, VLayout: ascii.LayoutSmush}},
+		{name: "vertical fitting", opts: ascii.Options{VLayout: ascii.LayoutFit}},
+		{name: "wrapping with hyphens", opts: ascii.Options{Width: 70, Hyphenate: true}},
+		{name: "fallback replacement", opts: ascii.Options{Fallback: ascii.Fallback{Mode: ascii.FallbackReplace}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := ascii.Render(text, font, tt.opts)
+
+			for _, workers := range []int{2, 3, 8} {
+				opts := tt.opts
+				opts.Workers = workers
+				got := ascii.Render(text, font, opts)
+
+				if !equalSlices(got.Lines(), want.Lines()) {
+					t.Fatalf("%d workers: output differs from the serial renderer", workers)
+				}
+				// Cell sources must match too, or color would differ
+				for i := range want.Rows {
+					for j := range want.Rows[i] {
+						if got.Rows[i][j] != want.Rows[i][j] {
+							t.Fatalf("%d workers: cell %d,%d = %+v, want %+v", workers, i, j, got.Rows[i][j], want.Rows[i][j])
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestHandleStreamParallelIsByteIdentical(t *testing.T) {
+	font := standardFont(t)
+	text := parallelText(300) + "\n"
+
+	configs := []input.StreamConfig{
+		{Font: font},
+		{Font: font, Options: ascii.Options{VLayout: ascii.LayoutSmush}, Color: color.ColorConfig{Enabled: true, Color: "red", Substring: "o"}},
+		{Font: font, Options: ascii.Options{Width: 90}, Align: "center", Width: 90},
+	}
+
+	for i, cfg := range configs {
+		var serial strings.Builder
+		if err := input.HandleStream(strings.NewReader(text), &serial, cfg); err != nil {
+			t.Fatalf("config %d: serial error: %v", i, err)
+		}
+
+		cfg.Options.Workers = 4
+		var parallel strings.Builder
+		if err := input.HandleStream(strings.NewReader(text), &parallel, cfg); err != nil {
+			t.Fatalf("config %d: parallel error: %v", i, err)
+		}
+
+		if serial.String() != parallel.String() {
+			t.Errorf("config %d: parallel output is not byte-identical to serial output", i)
+		}
+	}
+}
+
+func TestParallelStreamEmitsInOrder(t *testing.T) {
+	font := standardFont(t)
+
+	var got []string
+	p := ascii.NewParallelStream(font, ascii.Options{}, 4, func(line string, _ *ascii.Canvas) error {
+		got = append(got, line)
+		return nil
+	})
+	var want []string
+	for i := 0; i < 200; i++ {
+		line := fmt.Sprint(i)
+		want = append(want, line)
+		if err := p.Add(line); err != nil {
+			t.Fatalf("Add() unexpected error = %v", err)
+		}
+	}
+	if _, err := p.Close(); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+
+	if !equalSlices(got, want) {
+		t.Errorf("lines emitted out of order: %q", got)
+	}
+}
+
+func TestParallelStreamStopsOnEmitError(t *testing.T) {
+	font := standardFont(t)
+	errStop := errors.New("stop")
+
+	emitted := 0
+	p := ascii.NewParallelStream(font, ascii.Options{}, 2, func(string, *ascii.Canvas) error {
+		emitted++
+		if emitted == 3 {
+			return errStop
+		}
+		return nil
+	})
+
+	for i := 0; i < 100; i++ {
+		if err := p.Add("line"); err != nil {
+			break
+		}
+	}
+	if _, err := p.Close(); !errors.Is(err, errStop) {
+		t.Errorf("Close() error = %v, want %v", err, errStop)
+	}
+	if emitted != 3 {
+		t.Errorf("emit called %d times after failing, want 3", emitted)
+	}
+}
+
+func TestParseWorkersFlag(t *testing.T) {
+	workers, args, err := ascii.ParseWorkersFlag([]string{"--workers=4", "--input=big.log"})
+	if err != nil || workers != 4 || !equalSlices(args, []string{"--input=big.log"}) {
+		t.Errorf("ParseWorkersFlag() = %d, %q, %v", workers, args, err)
+	}
+
+	if workers, _, err := ascii.ParseWorkersFlag([]string{"--workers=auto"}); err != nil || workers < 1 {
+		t.Errorf("ParseWorkersFlag(auto) = %d, %v", workers, err)
+	}
+	if workers, _, _ := ascii.ParseWorkersFlag([]string{"Hello"}); workers != 0 {
+		t.Errorf("ParseWorkersFlag() without the flag = %d, want 0", workers)
+	}
+	for _, arg := range []string{"--workers", "--workers=", "--workers=0", "--workers=many"} {
+		if _, _, err := ascii.ParseWorkersFlag([]string{arg}); err == nil {
+			t.Errorf("ParseWorkersFlag(%q) expected an error", arg)
+		}
+	}
+}
@@ -86,7 +86,7 @@ func TestParseAsciiArt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := reverse.ParseAsciiArt(tt.content)
+			got, err := reverse.ParseAsciiArt(tt.content, 8)
 			if err != nil {
 				t.Errorf("ParseAsciiArt() error = %v", err)
 				return
@@ -224,7 +224,7 @@ func TestSplitChunkIntoCharacters(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := reverse.SplitChunkIntoCharacters(tt.chunk, tt.charWidth)
+			got := reverse.SplitChunkIntoCharacters(tt.chunk, tt.charWidth, 8)
 
 			if len(got) != tt.wantCount {
 				t.Errorf("SplitChunkIntoCharacters() returned %d characters, want %d", len(got), tt.wantCount)
@@ -253,7 +253,7 @@ func TestSplitChunkIntoCharacters(t *testing.T) {
 func TestSplitChunkIntoCharactersInvalidInput(t *testing.T) {
 	// Test with wrong number of lines
 	chunk := []string{"line1", "line2"}
-	got := reverse.SplitChunkIntoCharacters(chunk, 4)
+	got := reverse.SplitChunkIntoCharacters(chunk, 4, 8)
 
 	if got != nil {
 		t.Errorf("SplitChunkIntoCharacters() with invalid chunk = %v, want nil", got)
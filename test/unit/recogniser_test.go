@@ -1,13 +1,14 @@
 package unit
 
 import (
+	"ascii-art/internal/ascii"
 	reverse "ascii-art/internal/ascii-reverse"
 	"testing"
 )
 
 func TestRecogniseCharacter(t *testing.T) {
 	// Create simple templates for testing
-	templates := map[rune][]string{
+	templates := ascii.NewFont("test", map[rune][]string{
 		' ': {
 			"      ",
 			"      ",
@@ -38,7 +39,7 @@ func TestRecogniseCharacter(t *testing.T) {
 			"       ",
 			"       ",
 		},
-	}
+	})
 
 	tests := []struct {
 		name    string
@@ -133,7 +134,7 @@ func TestRecogniseCharacter(t *testing.T) {
 
 func TestRecogniseText(t *testing.T) {
 	// Create templates for testing - ALL MUST HAVE SAME WIDTH (6 chars)
-	templates := map[rune][]string{
+	templates := ascii.NewFont("test", map[rune][]string{
 		' ': {
 			"      ",
 			"      ",
@@ -164,7 +165,7 @@ func TestRecogniseText(t *testing.T) {
 			"      ",
 			"      ",
 		},
-	}
+	})
 
 	tests := []struct {
 		name    string
@@ -345,7 +346,7 @@ func TestRecogniseTextWithBanner(t *testing.T) {
 
 func TestRecogniseCharacterNormalization(t *testing.T) {
 	// Test that normalization works - patterns with different trailing spaces should match
-	templates := map[rune][]string{
+	templates := ascii.NewFont("test", map[rune][]string{
 		'a': {
 			"  __  ",
 			" /  \\ ",
@@ -356,7 +357,7 @@ func TestRecogniseCharacterNormalization(t *testing.T) {
 			"      ",
 			"      ",
 		},
-	}
+	})
 
 	// Pattern without trailing spaces on some lines
 	pattern := []string{
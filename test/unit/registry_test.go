@@ -0,0 +1,195 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTinyBanner writes a one row banner called name into dir where every glyph is its own character
+func writeTinyBanner(t *testing.T, dir, name string) {
+	t.Helper()
+
+	var sb strings.Builder
+	sb.WriteString("# height: 1\n")
+	for ch := rune(32); ch <= 126; ch++ {
+		sb.WriteString(string(ch) + "\n\n")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name+".txt"), []byte(sb.String()), 0644); err != nil {
+		t.Fatalf("Failed to write banner: %v", err)
+	}
+}
+
+func TestNewRegistrySearchPath(t *testing.T) {
+	flagDir, envA, envB, config := t.TempDir(), t.TempDir(), t.TempDir(), t.TempDir()
+	t.Setenv(ascii.BannerPathEnv, envA+string(os.PathListSeparator)+envB)
+	t.Setenv("XDG_CONFIG_HOME", config)
+
+	registry := ascii.NewRegistry(flagDir)
+
+	var got []string
+	for _, source := range registry.Sources {
+		got = append(got, source.Name)
+	}
+	// ./banners sits after --banner-dir and ASCII_ART_BANNER_PATH and before the user's config directory
+	want := []string{flagDir, envA, envB, ascii.BannerDir, filepath.Join(config, "ascii-art", "banners"), "built-in"}
+	if !equalSlices(got, want) {
+		t.Errorf("search path = %v, want %v", got, want)
+	}
+}
+
+func TestNewRegistryConfigDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(ascii.BannerPathEnv, "")
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name string
+		xdg  string
+		want string
+	}{
+		{name: "XDG_CONFIG_HOME", xdg: filepath.Join(home, "xdg"), want: filepath.Join(home, "xdg", "ascii-art", "banners")},
+		{name: "unset", xdg: "", want: filepath.Join(home, ".config", "ascii-art", "banners")},
+		{name: "relative is ignored", xdg: "config", want: filepath.Join(home, ".config", "ascii-art", "banners")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", tt.xdg)
+			sources := ascii.NewRegistry("").Sources
+			if len(sources) != 3 || sources[0].Name != ascii.BannerDir || sources[1].Name != tt.want {
+				t.Errorf("search path = %v, want [%s %s built-in]", sources, ascii.BannerDir, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkingDirBannersOverrideConfig(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv(ascii.BannerPathEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", config)
+
+	configBanners := filepath.Join(config, "ascii-art", "banners")
+	if err := os.MkdirAll(configBanners, 0755); err != nil {
+		t.Fatalf("Failed to create config banners: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configBanners, "local.txt"), bannerFile([]string{"# height: 2"}, 2, nil), 0644); err != nil {
+		t.Fatalf("Failed to write banner: %v", err)
+	}
+	if err := os.MkdirAll(ascii.BannerDir, 0755); err != nil {
+		t.Fatalf("Failed to create banners directory: %v", err)
+	}
+	defer os.RemoveAll(ascii.BannerDir)
+	writeTinyBanner(t, ascii.BannerDir, "local")
+
+	// A banner in ./banners wins over the same name in the config directory
+	font, err := ascii.NewRegistry("").Load("local")
+	if err != nil {
+		t.Fatalf("Load(local) unexpected error = %v", err)
+	}
+	if font.Height != 1 {
+		t.Errorf("Expected the banner from ./banners, got height %d", font.Height)
+	}
+}
+
+func TestRegistryPrecedenceAndNames(t *testing.T) {
+	flagDir, envDir := t.TempDir(), t.TempDir()
+	t.Setenv(ascii.BannerPathEnv, envDir)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	writeTinyBanner(t, flagDir, "standard")
+	writeTinyBanner(t, envDir, "custom")
+
+	registry := ascii.NewRegistry(flagDir)
+
+	// The flag directory overrides the built-in standard banner
+	font, err := registry.Load("standard")
+	if err != nil {
+		t.Fatalf("Load(standard) unexpected error = %v", err)
+	}
+	if font.Height != 1 {
+		t.Errorf("Expected the banner from --banner-dir, got height %d", font.Height)
+	}
+
+	if !registry.Has("custom") || registry.Has("missing") {
+		t.Error("Has() did not follow the search path")
+	}
+
+	want := []string{"custom", "shadow", "standard", "thinkertoy"}
+	if names := registry.Names(); !equalSlices(names, want) {
+		t.Errorf("Names() = %v, want %v", names, want)
+	}
+}
+
+func TestRegistryValidationUsesSearchPath(t *testing.T) {
+	dir := t.TempDir()
+	writeTinyBanner(t, dir, "custom")
+	t.Setenv(ascii.BannerPathEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	original := ascii.Banners
+	defer func() { ascii.Banners = original }()
+	ascii.Banners = ascii.NewRegistry(dir)
+
+	if !ascii.IsValidBanner("custom") {
+		t.Error("IsValidBanner(custom) = false for a banner in --banner-dir")
+	}
+
+	err := ascii.WrapInvalidBannerError("unknown")
+	if !errors.Is(err, ascii.ErrInvalidBanner) {
+		t.Errorf("WrapInvalidBannerError() does not wrap ErrInvalidBanner")
+	}
+	if !strings.Contains(err.Error(), "custom, shadow, standard, thinkertoy") {
+		t.Errorf("Expected the error to list the banners on the search path, got %q", err.Error())
+	}
+}
+
+func TestCheckBannerName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "standard"},
+		{name: "my-font_2"},
+		{name: "", wantErr: true},
+		{name: "..", wantErr: true},
+		{name: ".hidden", wantErr: true},
+		{name: "../etc/passwd", wantErr: true},
+		{name: "sub/standard", wantErr: true},
+		{name: `..\standard`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := ascii.CheckBannerName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckBannerName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	if _, err := ascii.LoadBannerFile("../banners/standard"); err == nil {
+		t.Error("LoadBannerFile() accepted a name with path traversal")
+	}
+}
+
+func TestParseBannerDirFlag(t *testing.T) {
+	dir := t.TempDir()
+
+	got, remaining, err := ascii.ParseBannerDirFlag([]string{"--banner-dir=" + dir, "Hello", "custom"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != dir || !equalSlices(remaining, []string{"Hello", "custom"}) {
+		t.Errorf("ParseBannerDirFlag() = %q, %v", got, remaining)
+	}
+
+	if _, _, err := ascii.ParseBannerDirFlag([]string{"--banner-dir", dir}); err != ascii.ErrInvalidBannerDirFormat {
+		t.Errorf("Expected ErrInvalidBannerDirFormat, got %v", err)
+	}
+	if _, _, err := ascii.ParseBannerDirFlag([]string{"--banner-dir=" + filepath.Join(dir, "missing")}); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
@@ -2,44 +2,79 @@ package unit_test
 
 import (
 	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
 	"bytes"
-	"os"
+	"slices"
 	"strings"
 	"testing"
 )
 
+// Mock banner font shared by the render tests
+var mockBanner = ascii.NewFont("mock", map[rune][]string{
+	'A': {
+		"   A   ",
+		"  A A  ",
+		" A   A ",
+		" AAAAA ",
+		" A   A ",
+		" A   A ",
+		" A   A ",
+		"       ",
+	},
+})
+
 func TestRenderAscii(t *testing.T) {
-	// Step 1: Create a mock banner map
-	mockBanner := map[rune][]string{
-		'A': {
-			"   A   ",
-			"  A A  ",
-			" A   A ",
-			" AAAAA ",
-			" A   A ",
-			" A   A ",
-			" A   A ",
-			"       ",
-		},
-	}
-
-	// Step 2: Capture stdout
+	// Step 1: Render into a buffer instead of stdout
 	var buf bytes.Buffer
-	stdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	// Step 3: Call RenderAscii with test input
-	ascii.RenderAscii("A", mockBanner)
-
-	// Step 4: Read and restore stdout
-	w.Close()
-	os.Stdout = stdout
-	buf.ReadFrom(r)
+	if err := ascii.RenderTo(&buf, "A", mockBanner); err != nil {
+		t.Fatalf("RenderTo returned unexpected error: %v", err)
+	}
 
-	// Step 5: Check output
-	expected := strings.Join(mockBanner['A'], "\n") + "\n" // fmt.Println adds newlines
+	// Step 2: Check output
+	expected := strings.Join(mockBanner.Glyphs['A'], "\n") + "\n" // every row ends with a newline
 	if buf.String() != expected {
 		t.Errorf("Expected:\n%q\nGot:\n%q", expected, buf.String())
 	}
 }
+
+func TestRenderLines(t *testing.T) {
+	lines := ascii.RenderLines("AA", mockBanner)
+	if len(lines) != 8 {
+		t.Fatalf("Expected 8 lines, got %d", len(lines))
+	}
+
+	for row, line := range lines {
+		expected := mockBanner.Glyphs['A'][row] + mockBanner.Glyphs['A'][row]
+		if line != expected {
+			t.Errorf("Row %d: expected %q, got %q", row, expected, line)
+		}
+	}
+}
+
+func TestRenderString(t *testing.T) {
+	got := ascii.RenderString("A", mockBanner)
+	expected := strings.Join(mockBanner.Glyphs['A'], "\n") + "\n"
+	if got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+// TestRenderEmptyLine checks that an empty line is one empty row with and without color, not a row per banner row
+func TestRenderEmptyLine(t *testing.T) {
+	want := append(append(append([]string{}, mockBanner.Glyphs['A']...), ""), mockBanner.Glyphs['A']...)
+
+	if got := ascii.RenderLines("A\n\nA", mockBanner); !slices.Equal(got, want) {
+		t.Errorf("RenderLines() = %q, want %q", got, want)
+	}
+	if got := ascii.Render("A\n\nA", mockBanner, ascii.Options{}).Lines(); !slices.Equal(got, want) {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	colored, err := color.Colorize(ascii.Render("A\n\nA", mockBanner, ascii.Options{}), "A\n\nA", color.ColorConfig{Enabled: true, Color: "red"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	if len(colored) != len(want) || colored[8] != "" {
+		t.Errorf("Colorize() = %q, want %d rows with an empty one in the middle", colored, len(want))
+	}
+}
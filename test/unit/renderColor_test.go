@@ -1,6 +1,7 @@
 package unit_test
 
 import (
+	"ascii-art/internal/ascii"
 	color "ascii-art/internal/ascii-color"
 	"testing"
 )
@@ -93,3 +94,32 @@ func TestBuildColorMap(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderColorLines(t *testing.T) {
+	banner := ascii.NewFont("test", map[rune][]string{
+		'A': {"A1", "A2", "A3", "A4", "A5", "A6", "A7", "A8"},
+		'B': {"B1", "B2", "B3", "B4", "B5", "B6", "B7", "B8"},
+	})
+	config := color.ColorConfig{Enabled: true, Color: "red", Substring: "B"}
+
+	lines, err := color.RenderColorLines("AB", banner, config)
+	if err != nil {
+		t.Fatalf("RenderColorLines() unexpected error = %v", err)
+	}
+	if len(lines) != 8 {
+		t.Fatalf("RenderColorLines() returned %d lines, want 8", len(lines))
+	}
+
+	red, _ := color.ParseColor("red")
+	want := "A1" + red + "B1" + color.ResetColor()
+	if lines[0] != want {
+		t.Errorf("RenderColorLines() line 0 = %q, want %q", lines[0], want)
+	}
+}
+
+func TestRenderColorLinesInvalidColor(t *testing.T) {
+	config := color.ColorConfig{Enabled: true, Color: "not-a-color"}
+	if _, err := color.RenderColorLines("A", ascii.NewFont("test", nil), config); err == nil {
+		t.Error("RenderColorLines() expected error for invalid color, got nil")
+	}
+}
@@ -0,0 +1,143 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	reverse "ascii-art/internal/ascii-reverse"
+	"strings"
+	"testing"
+)
+
+func TestRenderScale(t *testing.T) {
+	font := ascii.NewFont("scale", map[rune][]string{
+		'a': {"ab", "cd"},
+	})
+
+	tests := []struct {
+		name string
+		opts ascii.Options
+		want []string
+	}{
+		{name: "no scale", want: []string{"ab", "cd"}},
+		{name: "both ways", opts: ascii.Options{ScaleX: 2, ScaleY: 2}, want: []string{"aabb", "aabb", "ccdd", "ccdd"}},
+		{name: "across only", opts: ascii.Options{ScaleX: 3}, want: []string{"aaabbb", "cccddd"}},
+		{name: "down only", opts: ascii.Options{ScaleY: 2}, want: []string{"ab", "ab", "cd", "cd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ascii.Render("a", font, tt.opts).Lines(); !equalSlices(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// An empty line is one empty row, repeated down
+	if got := ascii.Render("a\n\na", font, ascii.Options{ScaleY: 2}).Height(); got != 10 {
+		t.Errorf("Render() with an empty line = %d rows, want 10", got)
+	}
+}
+
+func TestScaleKeepsColorAndMeasure(t *testing.T) {
+	font := standardFont(t)
+	opts := ascii.Options{ScaleX: 2, ScaleY: 3}
+
+	width, height := justify.MeasureText("Hi", font, opts)
+	plainWidth, plainHeight := justify.MeasureText("Hi", font, ascii.Options{})
+	if width != 2*plainWidth || height != 3*plainHeight {
+		t.Errorf("MeasureText() = %dx%d, want %dx%d", width, height, 2*plainWidth, 3*plainHeight)
+	}
+
+	// Every scaled cell of the colored character is colored
+	canvas := ascii.Render("Hi", font, opts)
+	lines, err := color.Colorize(canvas, "Hi", color.ColorConfig{Enabled: true, Color: "red", Substring: "i"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	red, _ := color.ParseColor("red")
+	for i, line := range lines {
+		plain := canvas.Lines()[i]
+		want := plain[:2*font.Width('H')] + red + plain[2*font.Width('H'):] + color.ResetColor()
+		if line != want {
+			t.Errorf("line %d = %q, want %q", i, line, want)
+		}
+	}
+}
+
+func TestScaleWrapsToScaledWidth(t *testing.T) {
+	font := standardFont(t)
+	opts := ascii.Options{ScaleX: 2, Width: 60}
+
+	for _, line := range ascii.Render("abc def ghi", font, opts).Lines() {
+		if justify.GetLineWidth(line) > 60 {
+			t.Errorf("scaled line is %d columns wide, want at most 60", justify.GetLineWidth(line))
+		}
+	}
+}
+
+func TestParseScaleFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		wantX int
+		wantY int
+		rest  []string
+		isErr bool
+	}{
+		{name: "both", args: []string{"--scale=2", "Hi"}, wantX: 2, wantY: 2, rest: []string{"Hi"}},
+		{name: "override one axis", args: []string{"--scale-y=1", "--scale=3"}, wantX: 3, wantY: 1},
+		{name: "one axis only", args: []string{"--scale-x=4"}, wantX: 4, wantY: 0},
+		{name: "absent", args: []string{"Hi"}, rest: []string{"Hi"}},
+		{name: "missing =", args: []string{"--scale", "2"}, isErr: true},
+		{name: "zero", args: []string{"--scale=0"}, isErr: true},
+		{name: "not a number", args: []string{"--scale-x=big"}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y, rest, err := ascii.ParseScaleFlags(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParseScaleFlags() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseScaleFlags() unexpected error = %v", err)
+			}
+			if x != tt.wantX || y != tt.wantY || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParseScaleFlags() = %d, %d, %q", x, y, rest)
+			}
+		})
+	}
+}
+
+func TestReverseScaledArt(t *testing.T) {
+	font := standardFont(t)
+	text := "Hello 42\n\nok!"
+
+	for _, opts := range []ascii.Options{{}, {ScaleX: 2, ScaleY: 2}, {ScaleX: 3}, {ScaleY: 2}} {
+		art := strings.Join(ascii.Render(text, font, opts).Lines(), "\n") + "\n"
+
+		got, err := reverse.RecogniseTextWithFont(art, font)
+		if err != nil {
+			t.Fatalf("scale %dx%d: unexpected error = %v", opts.ScaleX, opts.ScaleY, err)
+		}
+		if got != text {
+			t.Errorf("scale %dx%d: got %q, want %q", opts.ScaleX, opts.ScaleY, got, text)
+		}
+	}
+}
+
+func TestDetectScale(t *testing.T) {
+	if x, y := reverse.DetectScale("aabb\naabb\nccdd\nccdd\n"); x != 2 || y != 2 {
+		t.Errorf("DetectScale() = %d, %d, want 2, 2", x, y)
+	}
+	if x, y := reverse.DetectScale("ab\ncd\n"); x != 1 || y != 1 {
+		t.Errorf("DetectScale() = %d, %d, want 1, 1", x, y)
+	}
+	if got := reverse.Downscale("aabb\naabb\nccdd\nccdd\n", 2, 2); got != "ab\ncd\n" {
+		t.Errorf("Downscale() = %q", got)
+	}
+}
@@ -0,0 +1,156 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	"testing"
+)
+
+func TestRenderShadow(t *testing.T) {
+	font := ascii.NewFont("shadow", map[rune][]string{
+		'a': {"/\\", "  "},
+		'b': {"#", "#"},
+	})
+
+	tests := []struct {
+		name   string
+		text   string
+		shadow ascii.Shadow
+		want   []string
+	}{
+		{name: "none", text: "a", want: []string{"/\\", "  "}},
+		{name: "offset", text: "a", shadow: ascii.Shadow{DX: 1, DY: 1, Char: '.'}, want: []string{"/\\ ", " ..", "   "}},
+		{name: "negative offset", text: "a", shadow: ascii.Shadow{DX: -1, DY: -1, Char: '.'}, want: []string{".. ", " /\\", "   "}},
+		{name: "own characters", text: "a", shadow: ascii.Shadow{DX: 2}, want: []string{"/\\/\\", "    "}},
+		{name: "art covers its shadow", text: "b", shadow: ascii.Shadow{DY: 1, Char: '.'}, want: []string{"#", "#", "."}},
+		{name: "extrusion", text: "b", shadow: ascii.Shadow{DX: 3, DY: 1, Char: '.', Extrude: true}, want: []string{"#.  ", "#...", "  .."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ascii.Render(tt.text, font, ascii.Options{Shadow: tt.shadow}).Lines()
+			if !equalSlices(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShadowGrowsMeasuredSize(t *testing.T) {
+	font := standardFont(t)
+	plainWidth, plainHeight := justify.MeasureText("Hi", font, ascii.Options{})
+
+	for _, shadow := range []ascii.Shadow{{DX: 2, DY: 1}, {DX: -3, DY: 2, Extrude: true}} {
+		width, height := justify.MeasureText("Hi", font, ascii.Options{Shadow: shadow})
+		columns, rows := shadow.Size()
+		if width != plainWidth+columns || height != plainHeight+rows {
+			t.Errorf("MeasureText() with %+v = %dx%d, want %dx%d", shadow, width, height, plainWidth+columns, plainHeight+rows)
+		}
+	}
+
+	// --width counts the shadow, so the art beside it wraps sooner
+	opts := ascii.Options{Shadow: ascii.Shadow{DX: 4, DY: 1}, Width: 40}
+	if canvas := ascii.Render("Hello there", font, opts); canvas.Width() > 40 {
+		t.Errorf("Render() = %d columns wide, want at most 40", canvas.Width())
+	}
+}
+
+func TestShadowColor(t *testing.T) {
+	font := ascii.NewFont("shadow", map[rune][]string{'b': {"#"}})
+	canvas := ascii.Render("bb", font, ascii.Options{Shadow: ascii.Shadow{DX: 1, Char: '.'}})
+	red, _ := color.ParseColor("red")
+	blue, _ := color.ParseColor("blue")
+	reset := color.ResetColor()
+
+	lines, err := color.Colorize(canvas, "bb", color.ColorConfig{Enabled: true, Color: "blue", Shadow: "red"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	if want := blue + "##" + red + "." + reset; lines[0] != want {
+		t.Errorf("Colorize() = %q, want %q", lines[0], want)
+	}
+}
+
+func TestShadowStreamsWholeBlock(t *testing.T) {
+	font := standardFont(t)
+	opts := ascii.Options{Shadow: ascii.Shadow{DX: 1, DY: 1, Char: '.'}}
+
+	stream := ascii.NewStream(font, opts)
+	for _, line := range []string{"ab", "cd"} {
+		if rows := stream.Add(line); rows.Height() != 0 {
+			t.Errorf("Add(%q) returned %d rows before the block was complete", line, rows.Height())
+		}
+	}
+	got := stream.Flush().Lines()
+	if want := ascii.Render("ab\ncd", font, opts).Lines(); !equalSlices(got, want) {
+		t.Errorf("Flush() = %q, want %q", got, want)
+	}
+}
+
+func TestJustifyShadowsWholeBlock(t *testing.T) {
+	font := standardFont(t)
+	shadow := ascii.Shadow{DX: 2, DY: 1, Char: '.'}
+
+	// The words are spread out in the columns the shadow leaves, then the block is shadowed
+	plain := justify.RenderWithJustify("ab cd", font, ascii.Options{}, 78)
+	got := justify.RenderWithJustify("ab cd", font, ascii.Options{Shadow: shadow}, 80)
+	if want := ascii.ShadowLines(plain, shadow); !equalSlices(got, want) {
+		t.Errorf("RenderWithJustify() = %q, want %q", got, want)
+	}
+}
+
+func TestParseShadowFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		want  ascii.Shadow
+		rest  []string
+		isErr bool
+	}{
+		{name: "shadow", args: []string{"--shadow=2,1,#", "Hi"}, want: ascii.Shadow{DX: 2, DY: 1, Char: '#'}, rest: []string{"Hi"}},
+		{name: "own characters", args: []string{"--shadow=-1,0"}, want: ascii.Shadow{DX: -1}},
+		{name: "comma", args: []string{"--shadow=1,1,,"}, want: ascii.Shadow{DX: 1, DY: 1, Char: ','}},
+		{name: "extrude", args: []string{"Hi", "--extrude=3,2,░"}, want: ascii.Shadow{DX: 3, DY: 2, Char: '░', Extrude: true}, rest: []string{"Hi"}},
+		{name: "absent", args: []string{"Hi"}, rest: []string{"Hi"}},
+		{name: "missing =", args: []string{"--shadow", "1,1"}, isErr: true},
+		{name: "empty", args: []string{"--extrude="}, isErr: true},
+		{name: "one number", args: []string{"--shadow=1"}, isErr: true},
+		{name: "no offset", args: []string{"--shadow=0,0"}, isErr: true},
+		{name: "not a number", args: []string{"--shadow=a,1"}, isErr: true},
+		{name: "several characters", args: []string{"--shadow=1,1,ab"}, isErr: true},
+		{name: "space", args: []string{"--shadow=1,1, "}, isErr: true},
+		{name: "both", args: []string{"--shadow=1,1", "--extrude=1,1"}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := ascii.ParseShadowFlags(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParseShadowFlags() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseShadowFlags() unexpected error = %v", err)
+			}
+			if got != tt.want || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParseShadowFlags() = %+v, %q", got, rest)
+			}
+		})
+	}
+}
+
+func TestParseShadowColorFlag(t *testing.T) {
+	got, rest, err := color.ParseShadowColorFlag([]string{"--shadow-color=rgb(0,0,255)", "Hi"})
+	if err != nil || got != "rgb(0,0,255)" || !equalSlices(rest, []string{"Hi"}) {
+		t.Errorf("ParseShadowColorFlag() = %q, %q, %v", got, rest, err)
+	}
+
+	for _, args := range [][]string{{"--shadow-color"}, {"--shadow-color="}, {"--shadow-color=nope"}} {
+		if _, _, err := color.ParseShadowColorFlag(args); err == nil {
+			t.Errorf("ParseShadowColorFlag(%q) expected an error", args)
+		}
+	}
+}
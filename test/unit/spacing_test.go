@@ -0,0 +1,176 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	reverse "ascii-art/internal/ascii-reverse"
+	"strings"
+	"testing"
+)
+
+// spacingFont has glyphs with blank edges so that negative spacing has room to overlap
+func spacingFont() *ascii.Font {
+	return ascii.NewFont("spacing", map[rune][]string{
+		'a': {"a  ", "aa "},
+		'b': {" bb", "  b"},
+	})
+}
+
+func TestRenderLetterSpacing(t *testing.T) {
+	tests := []struct {
+		name    string
+		spacing int
+		want    []string
+	}{
+		{name: "none", want: []string{"a   bb", "aa   b"}},
+		{name: "gap", spacing: 2, want: []string{"a     bb", "aa     b"}},
+		{name: "overlap", spacing: -2, want: []string{"a bb", "aa b"}},
+		{name: "overlap stops at ink", spacing: -5, want: []string{"abb", "aab"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ascii.Render("ab", spacingFont(), ascii.Options{LetterSpacing: tt.spacing}).Lines()
+			if !equalSlices(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderLineSpacing(t *testing.T) {
+	font := spacingFont()
+	opts := ascii.Options{LineSpacing: 2}
+
+	got := ascii.Render("a\n\nb", font, opts).Lines()
+	// Gaps are as wide as the line below them, so the one above an empty line is still not empty
+	want := []string{"a  ", "aa ", " ", " ", "", "   ", "   ", " bb", "  b"}
+	if !equalSlices(got, want) {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	// The gap is the same when lines are streamed one at a time or in parallel
+	for _, workers := range []int{0, 2} {
+		opts.Workers = workers
+		stream := ascii.NewStream(font, opts)
+		var lines []string
+		for _, line := range []string{"a", "", "b"} {
+			lines = append(lines, stream.Add(line).Lines()...)
+		}
+		lines = append(lines, stream.Flush().Lines()...)
+		if !equalSlices(lines, want) {
+			t.Errorf("streamed = %q, want %q", lines, want)
+		}
+		if got := ascii.Render("a\n\nb", font, opts).Lines(); !equalSlices(got, want) {
+			t.Errorf("Render() with %d workers = %q, want %q", workers, got, want)
+		}
+	}
+}
+
+func TestSpacingMeasureAndColor(t *testing.T) {
+	font := standardFont(t)
+	opts := ascii.Options{LetterSpacing: 3, LineSpacing: 1}
+
+	plainWidth, plainHeight := justify.MeasureText("Hi\nHi", font, ascii.Options{})
+	width, height := justify.MeasureText("Hi\nHi", font, opts)
+	if width != plainWidth+3 || height != plainHeight+1 {
+		t.Errorf("MeasureText() = %dx%d, want %dx%d", width, height, plainWidth+3, plainHeight+1)
+	}
+
+	// The gap before the colored i is not colored
+	canvas := ascii.Render("Hi", font, opts)
+	lines, err := color.Colorize(canvas, "Hi", color.ColorConfig{Enabled: true, Color: "red", Substring: "i"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	red, _ := color.ParseColor("red")
+	split := font.Width('H') + 3
+	for i, line := range lines {
+		plain := canvas.Lines()[i]
+		if want := plain[:split] + red + plain[split:] + color.ResetColor(); line != want {
+			t.Errorf("line %d = %q, want %q", i, line, want)
+		}
+	}
+}
+
+func TestVerticalSpacing(t *testing.T) {
+	font := spacingFont()
+	opts := ascii.Options{Direction: ascii.DirectionVertical, LetterSpacing: 1, LineSpacing: 2}
+
+	got := ascii.Render("ab\na", font, opts).Lines()
+	want := []string{"a    a  ", "aa   aa ", "        ", " bb     ", "  b     "}
+	if !equalSlices(got, want) {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestJustifyKeepsLetterSpacingBetweenWords(t *testing.T) {
+	font := spacingFont()
+
+	// The words don't fit, so they are centred with the letter spacing between them
+	got := justify.RenderWithJustify("ab ab", font, ascii.Options{LetterSpacing: 2}, 10)
+	want := []string{"a     bb  a     bb", "aa     b  aa     b"}
+	if !equalSlices(got, want) {
+		t.Errorf("RenderWithJustify() = %q, want %q", got, want)
+	}
+}
+
+func TestReverseSpacedArt(t *testing.T) {
+	font := standardFont(t)
+	text := "Hello 42\n\nok!"
+
+	for _, opts := range []ascii.Options{
+		{LetterSpacing: 2},
+		{LetterSpacing: -1},
+		{LineSpacing: 2},
+		{LetterSpacing: -2, LineSpacing: 1},
+		{LetterSpacing: 1, LineSpacing: 1, ScaleX: 2, ScaleY: 2},
+	} {
+		art := strings.Join(ascii.Render(text, font, opts).Lines(), "\n") + "\n"
+
+		got, err := reverse.RecogniseTextWithFont(art, font)
+		if err != nil {
+			t.Fatalf("%+v: unexpected error = %v", opts, err)
+		}
+		if got != text {
+			t.Errorf("%+v: got %q, want %q", opts, got, text)
+		}
+	}
+}
+
+func TestParseSpacingFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantLetter int
+		wantLine   int
+		rest       []string
+		isErr      bool
+	}{
+		{name: "both", args: []string{"--letter-spacing=-2", "--line-spacing=1", "Hi"}, wantLetter: -2, wantLine: 1, rest: []string{"Hi"}},
+		{name: "absent", args: []string{"Hi"}, rest: []string{"Hi"}},
+		{name: "missing =", args: []string{"--letter-spacing", "2"}, isErr: true},
+		{name: "empty", args: []string{"--line-spacing="}, isErr: true},
+		{name: "negative line spacing", args: []string{"--line-spacing=-1"}, isErr: true},
+		{name: "not a number", args: []string{"--letter-spacing=wide"}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			letter, line, rest, err := ascii.ParseSpacingFlags(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParseSpacingFlags() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSpacingFlags() unexpected error = %v", err)
+			}
+			if letter != tt.wantLetter || line != tt.wantLine || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParseSpacingFlags() = %d, %d, %q", letter, line, rest)
+			}
+		})
+	}
+}
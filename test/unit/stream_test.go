@@ -0,0 +1,138 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	input "ascii-art/internal/ascii-input"
+	"strings"
+	"testing"
+)
+
+func TestStreamMatchesRender(t *testing.T) {
+	font := vlayoutFont()
+	text := "o\nu\n\no_\n-u"
+
+	for _, vlayout := range []ascii.Layout{ascii.LayoutFull, ascii.LayoutFit, ascii.LayoutSmush} {
+		opts := ascii.Options{VLayout: vlayout}
+		stream := ascii.NewStream(font, opts)
+
+		var got []string
+		for _, line := range strings.Split(text, "\n") {
+			got = append(got, stream.Add(line).Lines()...)
+		}
+		got = append(got, stream.Flush().Lines()...)
+
+		want := ascii.Render(text, font, opts).Lines()
+		if !equalSlices(got, want) {
+			t.Errorf("vlayout %d: streamed %q, rendered %q", vlayout, got, want)
+		}
+	}
+}
+
+func TestStreamHoldsBackOverlappableRows(t *testing.T) {
+	font := vlayoutFont()
+
+	full := ascii.NewStream(font, ascii.Options{VLayout: ascii.LayoutFull})
+	if got := full.Add("o").Height(); got != 3 {
+		t.Errorf("full height stream returned %d rows, want 3", got)
+	}
+
+	fit := ascii.NewStream(font, ascii.Options{VLayout: ascii.LayoutFit})
+	if got := fit.Add("o").Height(); got != 0 {
+		t.Errorf("fitting stream returned %d rows before the next line, want 0", got)
+	}
+	if got := fit.Flush().Height(); got != 3 {
+		t.Errorf("Flush returned %d rows, want 3", got)
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "newline terminated", text: "a\nb\n", want: []string{"a", "b"}},
+		{name: "last line without newline", text: "a\nb", want: []string{"a", "b"}},
+		{name: "windows line endings", text: "a\r\nb\r\n", want: []string{"a", "b"}},
+		{name: "empty lines kept", text: "a\n\nb\n", want: []string{"a", "", "b"}},
+		{name: "empty input", text: "", want: nil},
+		{name: "long line", text: strings.Repeat("x", 100000), want: []string{strings.Repeat("x", 100000)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := input.ReadLines(strings.NewReader(tt.text), func(line string) error {
+				got = append(got, line)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalSlices(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleStreamMatchesRender(t *testing.T) {
+	font := standardFont(t)
+	text := "Hello\n\nworld"
+	colorConfig := color.ColorConfig{Enabled: true, Color: "red", Substring: "o"}
+
+	var sb strings.Builder
+	cfg := input.StreamConfig{Font: font, Color: colorConfig}
+	if err := input.HandleStream(strings.NewReader(text+"\n"), &sb, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want, err := color.RenderColorLines(text, font, colorConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n"); !equalSlices(got, want) {
+		t.Errorf("streamed output differs from rendering the whole text\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func TestHandleStreamAligns(t *testing.T) {
+	font := standardFont(t)
+
+	var sb strings.Builder
+	cfg := input.StreamConfig{Font: font, Align: "right", Width: 40}
+	if err := input.HandleStream(strings.NewReader("hi\n"), &sb, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n") {
+		if len(line) != 40 {
+			t.Errorf("line %q is %d columns wide, want 40", line, len(line))
+		}
+	}
+}
+
+func TestHandleStreamMissingGlyph(t *testing.T) {
+	font := standardFont(t)
+
+	var sb strings.Builder
+	cfg := input.StreamConfig{Font: font, Options: ascii.Options{Fallback: ascii.Fallback{Mode: ascii.FallbackError}}}
+	err := input.HandleStream(strings.NewReader("ok\nné\n"), &sb, cfg)
+	if err == nil {
+		t.Fatal("expected an error for a character missing from the banner")
+	}
+	if got := strings.Count(sb.String(), "\n"); got != 8 {
+		t.Errorf("expected the first line to be written before the error, got %d rows", got)
+	}
+}
+
+// standardFont loads the built-in standard banner
+func standardFont(t *testing.T) *ascii.Font {
+	t.Helper()
+	font, err := ascii.LoadBannerFile("standard")
+	if err != nil {
+		t.Fatalf("LoadBannerFile() unexpected error = %v", err)
+	}
+	return font
+}
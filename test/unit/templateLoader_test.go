@@ -73,14 +73,14 @@ func TestLoadBannerTemplates(t *testing.T) {
 
 			// Check that expected characters are present
 			for _, char := range tt.wantChars {
-				if _, exists := got[char]; !exists {
+				if _, exists := got.Glyphs[char]; !exists {
 					t.Errorf("LoadBannerTemplates() missing character %q", char)
 				}
 			}
 
 			// Check specific pattern if specified
 			if tt.checkPattern != 0 {
-				pattern, exists := got[tt.checkPattern]
+				pattern, exists := got.Glyphs[tt.checkPattern]
 				if !exists {
 					t.Errorf("LoadBannerTemplates() missing pattern for character %q", tt.checkPattern)
 					return
@@ -123,7 +123,15 @@ func TestGetCharacterWidth(t *testing.T) {
 				"| |  | |",
 				"|_|  |_|",
 			},
-			want: 9,
+			want: 8,
+		},
+		{
+			name: "multi-byte pattern",
+			pattern: []string{
+				"█ █",
+				"█▀█",
+			},
+			want: 3,
 		},
 		{
 			name: "empty pattern",
@@ -254,17 +262,17 @@ func TestLoadBannerTemplatesCharacterRange(t *testing.T) {
 	}
 
 	// Should have at least the space character
-	if len(templates) == 0 {
-		t.Error("LoadBannerTemplates() returned empty map")
+	if len(templates.Glyphs) == 0 {
+		t.Error("LoadBannerTemplates() returned empty font")
 	}
 
 	// Check that space character exists
-	if _, exists := templates[' ']; !exists {
+	if _, exists := templates.Glyphs[' ']; !exists {
 		t.Error("LoadBannerTemplates() missing space character")
 	}
 
 	// Check that each pattern has 8 lines
-	for char, pattern := range templates {
+	for char, pattern := range templates.Glyphs {
 		if len(pattern) != 8 {
 			t.Errorf("LoadBannerTemplates() character %q has %d lines, want 8", char, len(pattern))
 		}
@@ -1,5 +1,7 @@
 package unit
 
+import "strings"
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -27,3 +29,32 @@ func equalSlices(a, b []string) bool {
 	}
 	return true
 }
+
+// bannerLines returns the 95 classic glyphs of a banner, each height rows followed by a separator
+// The space glyph is blank, glyphs holds the rows of any other glyph to set, and the rest are drawn with "?"
+func bannerLines(height int, glyphs map[rune][]string) []string {
+	var lines []string
+	for ch := rune(32); ch <= 126; ch++ {
+		rows, ok := glyphs[ch]
+		switch {
+		case ok:
+		case ch == ' ':
+			rows = make([]string, height)
+			for i := range rows {
+				rows[i] = " "
+			}
+		default:
+			rows = make([]string, height)
+			for i := range rows {
+				rows[i] = "?"
+			}
+		}
+		lines = append(append(lines, rows...), "")
+	}
+	return lines
+}
+
+// bannerFile returns the text of a banner file with the header lines and the glyphs of bannerLines
+func bannerFile(header []string, height int, glyphs map[rune][]string) []byte {
+	return []byte(strings.Join(append(header, bannerLines(height, glyphs)...), "\n") + "\n")
+}
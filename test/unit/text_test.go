@@ -0,0 +1,85 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	"errors"
+	"testing"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		opts    ascii.TextOptions
+		want    string
+		wantErr error
+	}{
+		{name: "newline escape", text: `a\nb`, want: "a\nb"},
+		{name: "tab escape to default stop", text: `ab\tc`, want: "ab  c"},
+		{name: "tab at a stop takes a full stop", text: `abcd\te`, want: "abcd    e"},
+		{name: "tab stops restart on each line", text: "ab\tc\n\td", opts: ascii.TextOptions{TabWidth: 3}, want: "ab c\n   d"},
+		{name: "tab stops count characters, not bytes", text: "é\tx", opts: ascii.TextOptions{TabWidth: 2}, want: "é x"},
+		{name: "escaped backslash", text: `a\\nb`, want: `a\nb`},
+		{name: "hex escape", text: `\x41\x7e`, want: "A~"},
+		{name: "unicode escape", text: `\u{e9}\u{2588}`, want: "é█"},
+		{name: "unknown escape kept", text: `C:\data`, want: `C:\data`},
+		{name: "trailing backslash kept", text: `a\`, want: `a\`},
+		{name: "raw keeps escapes", text: `a\nb\t`, opts: ascii.TextOptions{Raw: true}, want: `a\nb\t`},
+		{name: "raw still expands real tabs", text: "a\tb", opts: ascii.TextOptions{Raw: true}, want: "a   b"},
+		{name: "control characters stripped", text: "a\x07b\x1b[0m", want: "ab[0m"},
+		{name: "decoded control characters stripped", text: `a\x07b`, want: "ab"},
+		{name: "control characters reported", text: "a\x07b", opts: ascii.TextOptions{Control: ascii.ControlError}, wantErr: ascii.ErrControlChar},
+		{name: "short hex escape", text: `\x4`, wantErr: ascii.ErrInvalidEscape},
+		{name: "bad hex digits", text: `\xZZ`, wantErr: ascii.ErrInvalidEscape},
+		{name: "unicode escape without braces", text: `\u00e9`, wantErr: ascii.ErrInvalidEscape},
+		{name: "unicode escape out of range", text: `\u{110000}`, wantErr: ascii.ErrInvalidEscape},
+		{name: "empty unicode escape", text: `\u{}`, wantErr: ascii.ErrInvalidEscape},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ascii.NormalizeText(tt.text, tt.opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("NormalizeText(%q) error = %v, want %v", tt.text, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeText(%q) unexpected error = %v", tt.text, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTextFlags(t *testing.T) {
+	raw, args, err := ascii.ParseRawFlag([]string{"--raw", "a\\n"})
+	if err != nil || !raw || !equalSlices(args, []string{"a\\n"}) {
+		t.Errorf("ParseRawFlag() = %v, %q, %v", raw, args, err)
+	}
+	if _, _, err := ascii.ParseRawFlag([]string{"--raw=on"}); !errors.Is(err, ascii.ErrInvalidRawFormat) {
+		t.Errorf("ParseRawFlag() with a value error = %v", err)
+	}
+
+	width, args, err := ascii.ParseTabWidthFlag([]string{"Hello", "--tab-width=8"})
+	if err != nil || width != 8 || !equalSlices(args, []string{"Hello"}) {
+		t.Errorf("ParseTabWidthFlag() = %d, %q, %v", width, args, err)
+	}
+	if _, _, err := ascii.ParseTabWidthFlag([]string{"--tab-width=0"}); err == nil {
+		t.Error("ParseTabWidthFlag() accepted a zero width")
+	}
+	if _, _, err := ascii.ParseTabWidthFlag([]string{"--tab-width"}); !errors.Is(err, ascii.ErrInvalidTabWidthFormat) {
+		t.Errorf("ParseTabWidthFlag() without = error = %v", err)
+	}
+
+	mode, _, err := ascii.ParseControlFlag([]string{"--control=error"})
+	if err != nil || mode != ascii.ControlError {
+		t.Errorf("ParseControlFlag() = %v, %v", mode, err)
+	}
+	if _, _, err := ascii.ParseControlFlag([]string{"--control=keep"}); !errors.Is(err, ascii.ErrInvalidControlFormat) {
+		t.Errorf("ParseControlFlag() unknown mode error = %v", err)
+	}
+}
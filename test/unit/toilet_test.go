@@ -0,0 +1,111 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	justify "ascii-art/internal/ascii-justify"
+	reverse "ascii-art/internal/ascii-reverse"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildToiletFont returns the lines of a 2-row TOIlet font that draws with block characters
+func buildToiletFont() []string {
+	lines := []string{
+		"tlf2a$ 2 1 8 -1 1",
+		"Block font for unit tests",
+	}
+
+	required := []rune{}
+	for ch := rune(32); ch <= 126; ch++ {
+		required = append(required, ch)
+	}
+	required = append(required, 196, 214, 220, 228, 246, 252, 223)
+
+	for _, ch := range required {
+		switch ch {
+		case ' ':
+			lines = append(lines, "$@", "$@@")
+		case 'H':
+			lines = append(lines, "█ █@", "█▀█@@")
+		case 'i':
+			lines = append(lines, "▄@", "█@@")
+		default:
+			lines = append(lines, "?@", "?@@")
+		}
+	}
+
+	return lines
+}
+
+func TestParseToiletFont(t *testing.T) {
+	font, err := ascii.ParseFiglet("block", buildToiletFont())
+	if err != nil {
+		t.Fatalf("ParseFiglet() unexpected error = %v", err)
+	}
+
+	if got := font.Glyphs['H']; !equalSlices(got, []string{"█ █", "█▀█"}) {
+		t.Errorf("glyph 'H' = %q", got)
+	}
+	if font.Width('H') != 3 || font.Width('i') != 1 {
+		t.Errorf("widths = %d, %d, want 3, 1", font.Width('H'), font.Width('i'))
+	}
+}
+
+func TestRenderToiletFont(t *testing.T) {
+	font, err := ascii.ParseFiglet("block", buildToiletFont())
+	if err != nil {
+		t.Fatalf("ParseFiglet() unexpected error = %v", err)
+	}
+
+	lines := ascii.RenderLines("Hi", font)
+	want := []string{"█ █▄", "█▀██"}
+	if !equalSlices(lines, want) {
+		t.Fatalf("RenderLines() = %q, want %q", lines, want)
+	}
+
+	if width, _ := justify.MeasureText("Hi", font, ascii.Options{}); width != 4 {
+		t.Errorf("MeasureText() width = %d, want 4", width)
+	}
+
+	// Alignment pads by columns, not bytes
+	centered := justify.CenterAlign(lines, 10)
+	if centered[0] != "   █ █▄" {
+		t.Errorf("CenterAlign() = %q, want 3 spaces of padding", centered[0])
+	}
+	right := justify.RightAlign(lines, 10)
+	if right[1] != "      █▀██" {
+		t.Errorf("RightAlign() = %q, want 6 spaces of padding", right[1])
+	}
+
+	// The recogniser reads the art back with the same font
+	chunks, err := reverse.ParseAsciiArt(strings.Join(lines, "\n"), font.Height)
+	if err != nil {
+		t.Fatalf("ParseAsciiArt() unexpected error = %v", err)
+	}
+	text, err := reverse.RecogniseText(chunks, font)
+	if err != nil || text != "Hi" {
+		t.Errorf("RecogniseText() = %q, %v, want %q", text, err, "Hi")
+	}
+}
+
+func TestToiletFontAsBanner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "block.tlf")
+	content := strings.Join(buildToiletFont(), "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write font: %v", err)
+	}
+
+	if !ascii.IsValidBanner(path) {
+		t.Fatalf("IsValidBanner(%q) = false, want true", path)
+	}
+
+	font, err := ascii.LoadBannerFile(path)
+	if err != nil {
+		t.Fatalf("LoadBannerFile() unexpected error = %v", err)
+	}
+	if font.Name != "block" || !font.Has('H') {
+		t.Errorf("LoadBannerFile() loaded %q without its glyphs", font.Name)
+	}
+}
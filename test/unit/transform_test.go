@@ -0,0 +1,131 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	"strings"
+	"testing"
+)
+
+func TestRenderTransform(t *testing.T) {
+	font := ascii.NewFont("transform", map[rune][]string{
+		'a': {"/|", "-("},
+		'b': {"x", "y"},
+	})
+
+	tests := []struct {
+		name      string
+		transform ascii.Transform
+		want      []string
+	}{
+		{name: "none", want: []string{"/|", "-("}},
+		{name: "mirror", transform: ascii.TransformMirror, want: []string{"|\\", ")-"}},
+		{name: "flip", transform: ascii.TransformFlip, want: []string{"-(", "\\|"}},
+		{name: "rotate180", transform: ascii.TransformRotate180, want: []string{")-", "|/"}},
+		{name: "rotate90", transform: ascii.TransformRotate90, want: []string{"|\\", "(-"}},
+		{name: "rotate270", transform: ascii.TransformRotate270, want: []string{"-(", "\\|"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ascii.Render("a", font, ascii.Options{Transform: tt.transform}).Lines()
+			if !equalSlices(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Short rows are padded so the block keeps its shape
+	got := ascii.Render("b\na", font, ascii.Options{Transform: ascii.TransformMirror}).Lines()
+	if want := []string{" x", " y", "|\\", ")-"}; !equalSlices(got, want) {
+		t.Errorf("Render() mirrored = %q, want %q", got, want)
+	}
+}
+
+func TestTransformMeasureAndColor(t *testing.T) {
+	font := standardFont(t)
+
+	plainWidth, plainHeight := justify.MeasureText("Hi", font, ascii.Options{})
+	width, height := justify.MeasureText("Hi", font, ascii.Options{Transform: ascii.TransformRotate90})
+	if width != plainHeight || height != plainWidth {
+		t.Errorf("MeasureText() rotated = %dx%d, want %dx%d", width, height, plainHeight, plainWidth)
+	}
+
+	// The colored H moves to the right of the mirrored art
+	opts := ascii.Options{Transform: ascii.TransformMirror}
+	canvas := ascii.Render("Hi", font, opts)
+	lines, err := color.Colorize(canvas, "Hi", color.ColorConfig{Enabled: true, Color: "red", Substring: "H"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	red, _ := color.ParseColor("red")
+	split := canvas.Width() - font.Width('H')
+	for i, line := range lines {
+		plain := canvas.Lines()[i]
+		want := plain[:split] + red + plain[split:] + color.ResetColor()
+		if line != want {
+			t.Errorf("line %d = %q, want %q", i, line, want)
+		}
+	}
+}
+
+func TestTransformStreamsWholeBlock(t *testing.T) {
+	font := standardFont(t)
+	opts := ascii.Options{Transform: ascii.TransformFlip}
+
+	stream := ascii.NewStream(font, opts)
+	for _, line := range []string{"ab", "cd"} {
+		if rows := stream.Add(line); rows.Height() != 0 {
+			t.Errorf("Add(%q) returned %d rows before the block was complete", line, rows.Height())
+		}
+	}
+	got := stream.Flush().Lines()
+	if want := ascii.Render("ab\ncd", font, opts).Lines(); !equalSlices(got, want) {
+		t.Errorf("Flush() = %q, want %q", got, want)
+	}
+}
+
+func TestJustifyTransformsWholeBlock(t *testing.T) {
+	font := standardFont(t)
+	plain := justify.RenderWithJustify("ab cd", font, ascii.Options{}, 80)
+	got := justify.RenderWithJustify("ab cd", font, ascii.Options{Transform: ascii.TransformMirror}, 80)
+
+	if want := ascii.TransformLines(plain, ascii.TransformMirror); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("RenderWithJustify() = %q, want %q", got, want)
+	}
+}
+
+func TestParseTransformFlag(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		want  ascii.Transform
+		rest  []string
+		isErr bool
+	}{
+		{name: "rotate", args: []string{"--transform=rotate270", "Hi"}, want: ascii.TransformRotate270, rest: []string{"Hi"}},
+		{name: "absent", args: []string{"Hi"}, want: ascii.TransformNone, rest: []string{"Hi"}},
+		{name: "missing =", args: []string{"--transform", "flip"}, isErr: true},
+		{name: "empty", args: []string{"--transform="}, isErr: true},
+		{name: "unknown", args: []string{"--transform=spin"}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := ascii.ParseTransformFlag(tt.args)
+			if tt.isErr {
+				if err == nil {
+					t.Error("ParseTransformFlag() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTransformFlag() unexpected error = %v", err)
+			}
+			if got != tt.want || !equalSlices(rest, tt.rest) {
+				t.Errorf("ParseTransformFlag() = %v, %q", got, rest)
+			}
+		})
+	}
+}
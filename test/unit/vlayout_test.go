@@ -0,0 +1,92 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	justify "ascii-art/internal/ascii-justify"
+	"strings"
+	"testing"
+)
+
+// vlayoutFont is a three row font for checking how lines of text are stacked
+func vlayoutFont() *ascii.Font {
+	return ascii.NewFont("vlayout", map[rune][]string{
+		'o': {"oo", "oo", "  "},
+		'u': {"  ", "uu", "uu"},
+		'_': {"  ", "  ", "__"},
+		'-': {"--", "  ", "  "},
+	})
+}
+
+func TestRenderVerticalLayoutModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		vlayout ascii.Layout
+		want    []string
+	}{
+		{name: "full height stacks blocks", input: "o\nu", vlayout: ascii.LayoutFull, want: []string{"oo", "oo", "  ", "  ", "uu", "uu"}},
+		{name: "fitting removes blank rows", input: "o\nu", vlayout: ascii.LayoutFit, want: []string{"oo", "oo", "uu", "uu"}},
+		{name: "fitting stops at touching ink", input: "o\no", vlayout: ascii.LayoutFit, want: []string{"oo", "oo", "oo", "oo", "  "}},
+		{name: "equal character rule", input: "o\no", vlayout: ascii.LayoutSmush, want: []string{"oo", "oo", "oo", "  "}},
+		{name: "horizontal line rule", input: "_\n-", vlayout: ascii.LayoutSmush, want: []string{"  ", "  ", "==", "  ", "  "}},
+		{name: "empty line is kept", input: "o\n\nu", vlayout: ascii.LayoutFit, want: []string{"oo", "oo", "  ", "", "  ", "uu", "uu"}},
+		{name: "default uses font header", input: "o\nu", vlayout: ascii.LayoutDefault, want: []string{"oo", "oo", "  ", "  ", "uu", "uu"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := ascii.Render(tt.input, vlayoutFont(), ascii.Options{VLayout: tt.vlayout}).Lines()
+			if !equalSlices(lines, tt.want) {
+				t.Errorf("Render(%q) = %q, want %q", tt.input, lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestFontHeaderVerticalLayout(t *testing.T) {
+	font := vlayoutFont()
+	font.SetVerticalLayout(ascii.LayoutFit)
+
+	if mode, _ := font.VerticalLayout(); mode != ascii.LayoutFit {
+		t.Fatalf("VerticalLayout() = %v, want fit", mode)
+	}
+
+	lines := ascii.Render("o\nu", font, ascii.Options{})
+	if lines.Height() != 4 {
+		t.Errorf("Expected the header fit layout to give 4 rows, got %d", lines.Height())
+	}
+}
+
+func TestBannerHeaderVerticalLayout(t *testing.T) {
+	font, err := ascii.ParseBanner("vtest", append([]string{"# vlayout: smush", ""}, bannerLines(1, nil)...))
+	if err != nil {
+		t.Fatalf("ParseBanner failed: %v", err)
+	}
+
+	mode, rules := font.VerticalLayout()
+	if mode != ascii.LayoutSmush || rules != ascii.VSmushAllRules {
+		t.Errorf("VerticalLayout() = %v, %d, want smush with all rules", mode, rules)
+	}
+}
+
+func TestMeasureTextReportsFittedHeight(t *testing.T) {
+	_, full := justify.MeasureText("o\nu", vlayoutFont(), ascii.Options{})
+	_, fit := justify.MeasureText("o\nu", vlayoutFont(), ascii.Options{VLayout: ascii.LayoutFit})
+
+	if full != 6 || fit != 4 {
+		t.Errorf("Expected heights 6 and 4, got %d and %d", full, fit)
+	}
+}
+
+func TestStackLinesMatchesRender(t *testing.T) {
+	font := vlayoutFont()
+	opts := ascii.Options{VLayout: ascii.LayoutSmush}
+
+	blocks := [][]string{ascii.RenderLines("o", font), ascii.RenderLines("o", font)}
+	got := ascii.StackLines(blocks, font, opts)
+	want := ascii.Render("o\no", font, opts).Lines()
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("StackLines() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package unit
+
+import (
+	"ascii-art/internal/ascii"
+	color "ascii-art/internal/ascii-color"
+	justify "ascii-art/internal/ascii-justify"
+	"strings"
+	"testing"
+)
+
+// letterFont is a one row font where every glyph is its own character, one column wide
+func letterFont() *ascii.Font {
+	glyphs := map[rune][]string{' ': {" "}, '-': {"-"}}
+	for ch := 'a'; ch <= 'z'; ch++ {
+		glyphs[ch] = []string{string(ch)}
+	}
+	return ascii.NewFont("letters", glyphs)
+}
+
+func TestRenderWraps(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  ascii.Options
+		want  []string
+	}{
+		{name: "no width never wraps", input: "ab cd efghij", want: []string{"ab cd efghij"}},
+		{name: "breaks at word boundaries", input: "ab cd ef", opts: ascii.Options{Width: 5}, want: []string{"ab cd", "ef"}},
+		{name: "drops the spaces at a break", input: "ab   cd", opts: ascii.Options{Width: 3}, want: []string{"ab", "cd"}},
+		{name: "breaks long words between characters", input: "ab efghij", opts: ascii.Options{Width: 4}, want: []string{"ab", "efgh", "ij"}},
+		{name: "hyphenates long words", input: "ab efghij", opts: ascii.Options{Width: 4, Hyphenate: true}, want: []string{"ab", "efg-", "hij"}},
+		{name: "each line wraps on its own", input: "ab cd\n\nef gh", opts: ascii.Options{Width: 3}, want: []string{"ab", "cd", "", "ef", "gh"}},
+		{name: "narrow width still makes progress", input: "abc", opts: ascii.Options{Width: 1, Hyphenate: true}, want: []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := ascii.Render(tt.input, letterFont(), tt.opts).Lines()
+			if !equalSlices(lines, tt.want) {
+				t.Errorf("Render(%q) = %q, want %q", tt.input, lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapLine(t *testing.T) {
+	got := ascii.WrapLine("ab efghij", letterFont(), ascii.Options{Width: 4, Hyphenate: true})
+	if !equalSlices(got, []string{"ab", "efg-", "hij"}) {
+		t.Errorf("WrapLine() = %q", got)
+	}
+}
+
+func TestWrapWidth(t *testing.T) {
+	terminalWidth := func() int { return 60 }
+
+	tests := []struct {
+		name     string
+		width    int
+		terminal bool
+		want     int
+	}{
+		{name: "terminal wraps to its width", terminal: true, want: 60},
+		{name: "file or pipe is not wrapped", want: 0},
+		{name: "--width wins on a terminal", width: 30, terminal: true, want: 30},
+		{name: "--width wraps a file or pipe", width: 30, want: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ascii.WrapWidth(tt.width, tt.terminal, terminalWidth); got != tt.want {
+				t.Errorf("WrapWidth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapKeepsColorAndMeasure(t *testing.T) {
+	font := letterFont()
+	opts := ascii.Options{Width: 2}
+
+	// A colored substring that spans the break stays colored on both lines
+	canvas := ascii.Render("ab cd", font, opts)
+	lines, err := color.Colorize(canvas, "ab cd", color.ColorConfig{Enabled: true, Color: "red", Substring: "b c"})
+	if err != nil {
+		t.Fatalf("Colorize() unexpected error = %v", err)
+	}
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "a\033[") || !strings.HasPrefix(lines[1], "\033[") {
+		t.Errorf("Colorize() = %q, want b and c colored", lines)
+	}
+
+	width, height := justify.MeasureText("ab cd", font, opts)
+	if width != 2 || height != 2 {
+		t.Errorf("MeasureText() = %d x %d, want 2 x 2", width, height)
+	}
+
+	// Justify wraps each input line the same way
+	justified := justify.RenderWithJustify("ab cd", font, opts, 10)
+	if len(justified) != 2 {
+		t.Errorf("RenderWithJustify() = %q, want 2 lines", justified)
+	}
+}
+
+func TestParseWidthFlag(t *testing.T) {
+	width, remaining, err := ascii.ParseWidthFlag([]string{"--width=60", "Hello"})
+	if err != nil || width != 60 || !equalSlices(remaining, []string{"Hello"}) {
+		t.Errorf("ParseWidthFlag() = %d, %v, %v", width, remaining, err)
+	}
+
+	if width, _, err := ascii.ParseWidthFlag([]string{"Hello"}); err != nil || width != 0 {
+		t.Errorf("ParseWidthFlag() without the flag = %d, %v", width, err)
+	}
+	if _, _, err := ascii.ParseWidthFlag([]string{"--width", "60"}); err != ascii.ErrInvalidWidthFormat {
+		t.Errorf("Expected ErrInvalidWidthFormat, got %v", err)
+	}
+	for _, arg := range []string{"--width=0", "--width=-4", "--width=wide"} {
+		if _, _, err := ascii.ParseWidthFlag([]string{arg}); err == nil || !strings.Contains(err.Error(), "invalid width") {
+			t.Errorf("ParseWidthFlag(%q) error = %v, want invalid width", arg, err)
+		}
+	}
+}
+
+func TestParseHyphenateFlag(t *testing.T) {
+	hyphenate, remaining, err := ascii.ParseHyphenateFlag([]string{"Hello", "--hyphenate=on"})
+	if err != nil || !hyphenate || !equalSlices(remaining, []string{"Hello"}) {
+		t.Errorf("ParseHyphenateFlag() = %v, %v, %v", hyphenate, remaining, err)
+	}
+
+	for _, arg := range []string{"--hyphenate", "--hyphenate=", "--hyphenate=yes"} {
+		if _, _, err := ascii.ParseHyphenateFlag([]string{arg}); err != ascii.ErrInvalidHyphenateFormat {
+			t.Errorf("ParseHyphenateFlag(%q) error = %v, want ErrInvalidHyphenateFormat", arg, err)
+		}
+	}
+}